@@ -1,16 +1,20 @@
 package robinhood
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // Account holds the basic account details relevant to the RobinHood API
 type Account struct {
 	Meta
 	AccountNumber              string         `json:"account_number"`
-	BuyingPower                float64        `json:"buying_power,string"`
-	Cash                       float64        `json:"cash,string"`
-	CashAvailableForWithdrawal float64        `json:"cash_available_for_withdrawal,string"`
+	BrokerageAccountType       string         `json:"brokerage_account_type"`
+	BuyingPower                Decimal        `json:"buying_power"`
+	Cash                       Decimal        `json:"cash"`
+	CashAvailableForWithdrawal Decimal        `json:"cash_available_for_withdrawal"`
 	CashBalances               CashBalances   `json:"cash_balances"`
-	CashHeldForOrders          float64        `json:"cash_held_for_orders,string"`
+	CashHeldForOrders          Decimal        `json:"cash_held_for_orders"`
 	Deactivated                bool           `json:"deactivated"`
 	DepositHalted              bool           `json:"deposit_halted"`
 	MarginBalances             MarginBalances `json:"margin_balances"`
@@ -22,8 +26,8 @@ type Account struct {
 	SmaHeldForOrders           interface{}    `json:"sma_held_for_orders"`
 	SweepEnabled               bool           `json:"sweep_enabled"`
 	Type                       string         `json:"type"`
-	UnclearedDeposits          float64        `json:"uncleared_deposits,string"`
-	UnsettledFunds             float64        `json:"unsettled_funds,string"`
+	UnclearedDeposits          Decimal        `json:"uncleared_deposits"`
+	UnsettledFunds             Decimal        `json:"unsettled_funds"`
 	User                       string         `json:"user"`
 	WithdrawalHalted           bool           `json:"withdrawal_halted"`
 }
@@ -31,41 +35,102 @@ type Account struct {
 // CashBalances reflect the amount of cash available
 type CashBalances struct {
 	Meta
-	BuyingPower                float64 `json:"buying_power,string"`
-	Cash                       float64 `json:"cash,string"`
-	CashAvailableForWithdrawal float64 `json:"cash_available_for_withdrawal,string"`
-	CashHeldForOrders          float64 `json:"cash_held_for_orders,string"`
-	UnclearedDeposits          float64 `json:"uncleared_deposits,string"`
-	UnsettledFunds             float64 `json:"unsettled_funds,string"`
+	BuyingPower                Decimal `json:"buying_power"`
+	Cash                       Decimal `json:"cash"`
+	CashAvailableForWithdrawal Decimal `json:"cash_available_for_withdrawal"`
+	CashHeldForOrders          Decimal `json:"cash_held_for_orders"`
+	UnclearedDeposits          Decimal `json:"uncleared_deposits"`
+	UnsettledFunds             Decimal `json:"unsettled_funds"`
 }
 
 // MarginBalances reflect the balance available in margin accounts
 type MarginBalances struct {
 	Meta
-	Cash                              float64 `json:"cash,string"`
-	CashAvailableForWithdrawal        float64 `json:"cash_available_for_withdrawal,string"`
-	CashHeldForOrders                 float64 `json:"cash_held_for_orders,string"`
-	DayTradeBuyingPower               float64 `json:"day_trade_buying_power,string"`
-	DayTradeBuyingPowerHeldForOrders  float64 `json:"day_trade_buying_power_held_for_orders,string"`
+	Cash                              Decimal `json:"cash"`
+	CashAvailableForWithdrawal        Decimal `json:"cash_available_for_withdrawal"`
+	CashHeldForOrders                 Decimal `json:"cash_held_for_orders"`
+	DayTradeBuyingPower               Decimal `json:"day_trade_buying_power"`
+	DayTradeBuyingPowerHeldForOrders  Decimal `json:"day_trade_buying_power_held_for_orders"`
 	DayTradeRatio                     float64 `json:"day_trade_ratio,string"`
-	MarginLimit                       float64 `json:"margin_limit,string"`
+	MarginLimit                       Decimal `json:"margin_limit"`
 	MarkedPatternDayTraderDate        string  `json:"marked_pattern_day_trader_date"`
-	OvernightBuyingPower              float64 `json:"overnight_buying_power,string"`
-	OvernightBuyingPowerHeldForOrders float64 `json:"overnight_buying_power_held_for_orders,string"`
+	OvernightBuyingPower              Decimal `json:"overnight_buying_power"`
+	OvernightBuyingPowerHeldForOrders Decimal `json:"overnight_buying_power_held_for_orders"`
 	OvernightRatio                    float64 `json:"overnight_ratio,string"`
-	UnallocatedMarginCash             float64 `json:"unallocated_margin_cash,string"`
-	UnclearedDeposits                 float64 `json:"uncleared_deposits,string"`
-	UnsettledFunds                    float64 `json:"unsettled_funds,string"`
+	UnallocatedMarginCash             Decimal `json:"unallocated_margin_cash"`
+	UnclearedDeposits                 Decimal `json:"uncleared_deposits"`
+	UnsettledFunds                    Decimal `json:"unsettled_funds"`
 }
 
 // GetAccounts returns all the accounts associated with a login/client.
 func (c *Client) GetAccounts(ctx context.Context) ([]Account, error) {
-	var r struct{ Results []Account }
-	err := c.GetAndDecode(ctx, EPAccounts, &r)
+	var as []Account
+	if err := c.getAll(ctx, EPAccounts, &as); err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
+// FindAccount returns the account with the given number.
+func (c *Client) FindAccount(ctx context.Context, number string) (*Account, error) {
+	return c.findAccount(ctx, func(a *Account) bool {
+		return a.AccountNumber == number
+	}, fmt.Sprintf("number %q", number))
+}
+
+// FindAccountType returns the first account of the given type, matching
+// either the margin type ("cash", "margin") or the brokerage account type
+// (e.g. "individual", "ira_roth").
+func (c *Client) FindAccountType(ctx context.Context, typ string) (*Account, error) {
+	return c.findAccount(ctx, func(a *Account) bool {
+		return a.Type == typ || a.BrokerageAccountType == typ
+	}, fmt.Sprintf("type %q", typ))
+}
+
+func (c *Client) findAccount(ctx context.Context, match func(*Account) bool, desc string) (*Account, error) {
+	as, err := c.GetAccounts(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return r.Results, err
+	for i := range as {
+		if match(&as[i]) {
+			return &as[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no account with %s", desc)
+}
+
+// SelectAccount makes the account with the given number the client's
+// Account, used by default for orders.
+func (c *Client) SelectAccount(ctx context.Context, number string) error {
+	a, err := c.FindAccount(ctx, number)
+	if err != nil {
+		return err
+	}
+	c.Account = a
+	return nil
+}
+
+// SelectAccountType makes the first account of the given type (see
+// FindAccountType) the client's Account.
+func (c *Client) SelectAccountType(ctx context.Context, typ string) error {
+	a, err := c.FindAccountType(ctx, typ)
+	if err != nil {
+		return err
+	}
+	c.Account = a
+	return nil
+}
+
+// account returns override if set, or else the client's selected account.
+func (c *Client) account(override *Account) (*Account, error) {
+	if override != nil {
+		return override, nil
+	}
+	if c.Account == nil {
+		return nil, fmt.Errorf("no account selected")
+	}
+	return c.Account, nil
 }
 
 // CryptoAccount holds the basic account details relevant to robinhood API
@@ -77,11 +142,9 @@ type CryptoAccount struct {
 
 // GetCryptoAccounts will return associated cryto account
 func (c *Client) GetCryptoAccounts(ctx context.Context) ([]CryptoAccount, error) {
-	var r struct{ Results []CryptoAccount }
-	err := c.GetAndDecode(ctx, EPCryptoAccount, &r)
-	if err != nil {
+	var as []CryptoAccount
+	if err := c.getAll(ctx, EPCryptoAccount, &as); err != nil {
 		return nil, err
 	}
-
-	return r.Results, err
+	return as, nil
 }
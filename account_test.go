@@ -0,0 +1,57 @@
+package robinhood
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiAccount(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	ira := srv.AddAccount(robinhoodtest.Account{Type: "cash", BrokerageAccountType: "ira_roth"})
+	spy := srv.AddInstrument(robinhoodtest.Instrument{Symbol: "SPY"})
+	srv.AddPosition(robinhoodtest.DefaultAccountNumber, robinhoodtest.Position{Instrument: spy.URL, Quantity: "10.0000"})
+	srv.AddPosition(ira.AccountNumber, robinhoodtest.Position{Instrument: spy.URL, Quantity: "3.0000"})
+	srv.SetPortfolio(ira.AccountNumber, robinhoodtest.Portfolio{Equity: "1200.00"})
+
+	c := dialTest(t, srv, WithAccountType("ira_roth"))
+	require.Equal(t, ira.AccountNumber, c.Account.AccountNumber)
+	require.NoError(t, c.SelectAccount(ctx, robinhoodtest.DefaultAccountNumber))
+	require.Equal(t, robinhoodtest.DefaultAccountNumber, c.Account.AccountNumber)
+	require.Error(t, c.SelectAccountType(ctx, "ira_traditional"))
+
+	iraAcct, err := c.FindAccount(ctx, ira.AccountNumber)
+	require.NoError(t, err)
+
+	ps, err := c.GetPositions(ctx, ForAccount(iraAcct))
+	require.NoError(t, err)
+	require.Len(t, ps, 1)
+	require.Equal(t, "3", ps[0].Quantity.String())
+
+	all, err := c.GetAllAccountPositions(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, "10", all[0].Positions[0].Quantity.String())
+	require.Equal(t, ira.AccountNumber, all[1].Account.AccountNumber)
+
+	opts := make([]GetPositionsParamsOptions, 1, 2)
+	opts[0] = ExcludeZeroPositions()
+	spare := opts[:2]
+	_, err = c.GetAllAccountPositions(ctx, opts...)
+	require.NoError(t, err)
+	require.Nil(t, spare[1], "the caller's backing array is not written to")
+
+	p, err := c.GetAccountPortfolio(ctx, iraAcct)
+	require.NoError(t, err)
+	require.Equal(t, "1200", p.Equity.String())
+
+	out, err := c.Order(ctx, &Instrument{URL: spy.URL, Symbol: "SPY"}, OrderOpts{
+		Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(400, 0), Account: iraAcct,
+	})
+	require.NoError(t, err)
+	require.Equal(t, iraAcct.URL, out.Account)
+}
@@ -0,0 +1,257 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultChainConcurrency is the number of expirations and types
+// ChainSnapshot fetches at once unless ChainFilter.Concurrency is set.
+const DefaultChainConcurrency = 4
+
+// ChainFilter selects the options ChainSnapshot fetches. The zero value
+// selects every call and put of every expiration.
+type ChainFilter struct {
+	// Expirations are the expiration dates to fetch. If empty, the
+	// nearest MaxExpirations dates of the chain are fetched.
+	Expirations []Date
+
+	// MaxExpirations limits the expirations fetched when Expirations is
+	// empty. Zero fetches them all.
+	MaxExpirations int
+
+	// MinStrike and MaxStrike, unless zero, bound the strikes fetched.
+	MinStrike, MaxStrike Decimal
+
+	// Type is "call" or "put" to fetch only one type of option, or empty
+	// to fetch both.
+	Type string
+
+	// Concurrency bounds the expirations and types fetched at once. Zero
+	// means DefaultChainConcurrency.
+	Concurrency int
+}
+
+// A ChainQuote is an option and its market data, which is nil if the API
+// has none.
+type ChainQuote struct {
+	Option *OptionInstrument
+	Quote  *MarketData
+}
+
+// A ChainStrike holds the call and put at one strike and expiration. Either
+// is nil if it is not listed or was not fetched.
+type ChainStrike struct {
+	Call, Put *ChainQuote
+}
+
+// A ChainSnapshot is the options of a chain, with their market data, laid
+// out by strike and expiration.
+type ChainSnapshot struct {
+	Chain *OptionChain
+
+	// Expirations are the expiration dates fetched, in order.
+	Expirations []Date
+	// Strikes are the strikes listed at any of the expirations, in
+	// increasing order.
+	Strikes []Decimal
+	// Matrix[i][j] holds the options at Strikes[i] that expire on
+	// Expirations[j].
+	Matrix [][]ChainStrike
+}
+
+// At returns the options at strike that expire on exp.
+func (s *ChainSnapshot) At(strike Decimal, exp Date) (ChainStrike, bool) {
+	i := sort.Search(len(s.Strikes), func(i int) bool { return s.Strikes[i].Cmp(strike) >= 0 })
+	if i == len(s.Strikes) || !s.Strikes[i].Equal(strike) {
+		return ChainStrike{}, false
+	}
+	for j, e := range s.Expirations {
+		if e.String() == exp.String() {
+			return s.Matrix[i][j], true
+		}
+	}
+	return ChainStrike{}, false
+}
+
+// ChainSnapshot fetches the options on symbol selected by f, and their
+// market data, fetching up to f.Concurrency expirations and types
+// concurrently.
+func (c *Client) ChainSnapshot(ctx context.Context, symbol string, f ChainFilter) (*ChainSnapshot, error) {
+	i, err := c.GetInstrumentForSymbol(ctx, symbol)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting instrument")
+	}
+	chains, err := c.GetOptionChains(ctx, i)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting option chains")
+	}
+	chain := pickChain(chains, symbol)
+	if chain == nil {
+		return nil, fmt.Errorf("no option chain for %s", symbol)
+	}
+	exps, err := f.expirations(chain)
+	if err != nil {
+		return nil, err
+	}
+	types := []string{"call", "put"}
+	if f.Type != "" {
+		types = []string{f.Type}
+	}
+
+	// quotes[j][t] holds the options expiring on exps[j] of types[t].
+	quotes := make([][][]ChainQuote, len(exps))
+	n := f.Concurrency
+	if n <= 0 {
+		n = DefaultChainConcurrency
+	}
+	sem := make(chan struct{}, n)
+	eg, ctx := errgroup.WithContext(ctx)
+	for j := range exps {
+		quotes[j] = make([][]ChainQuote, len(types))
+		for t := range types {
+			// shadow for safe closure access
+			j, t := j, t
+			eg.Go(func() error {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				defer func() { <-sem }()
+				qs, err := c.chainQuotes(ctx, chain, types[t], exps[j], f)
+				quotes[j][t] = qs
+				return errors.Wrapf(err, "error getting %ss expiring %s", types[t], exps[j])
+			})
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	s := &ChainSnapshot{Chain: chain, Expirations: exps}
+	seen := map[string]bool{}
+	for _, byType := range quotes {
+		for _, qs := range byType {
+			for _, q := range qs {
+				if k := q.Option.StrikePrice; !seen[k.String()] {
+					seen[k.String()] = true
+					s.Strikes = append(s.Strikes, k)
+				}
+			}
+		}
+	}
+	sort.Slice(s.Strikes, func(i, j int) bool { return s.Strikes[i].Cmp(s.Strikes[j]) < 0 })
+	row := make(map[string]int, len(s.Strikes))
+	s.Matrix = make([][]ChainStrike, len(s.Strikes))
+	for i, k := range s.Strikes {
+		row[k.String()] = i
+		s.Matrix[i] = make([]ChainStrike, len(exps))
+	}
+	for j, byType := range quotes {
+		for _, qs := range byType {
+			for k := range qs {
+				q := &qs[k]
+				cell := &s.Matrix[row[q.Option.StrikePrice.String()]][j]
+				if q.Option.Type == "put" {
+					cell.Put = q
+				} else {
+					cell.Call = q
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+// chainQuotes returns the options of chain of type typ that expire on exp
+// and lie within the strikes of f, joined with their market data.
+func (c *Client) chainQuotes(ctx context.Context, chain *OptionChain, typ string, exp Date, f ChainFilter) ([]ChainQuote, error) {
+	all, err := chain.GetInstrument(ctx, typ, exp)
+	if err != nil {
+		return nil, err
+	}
+	var opts []*OptionInstrument
+	for _, oi := range all {
+		k := oi.StrikePrice
+		if !f.MinStrike.IsZero() && k.Cmp(f.MinStrike) < 0 || !f.MaxStrike.IsZero() && k.Cmp(f.MaxStrike) > 0 {
+			continue
+		}
+		oi.c = c
+		opts = append(opts, oi)
+	}
+	if len(opts) == 0 {
+		return nil, nil
+	}
+
+	mds, err := c.MarketData(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	byURL := make(map[string]*MarketData, len(mds))
+	for _, md := range mds {
+		byURL[md.Instrument] = md
+	}
+	qs := make([]ChainQuote, len(opts))
+	for i, oi := range opts {
+		qs[i] = ChainQuote{Option: oi, Quote: byURL[oi.URL]}
+	}
+	return qs, nil
+}
+
+// pickChain returns the chain of chains whose symbol is symbol, rather than
+// one of an adjusted chain left by a corporate action, or the first chain.
+func pickChain(chains []*OptionChain, symbol string) *OptionChain {
+	for _, ch := range chains {
+		if strings.EqualFold(ch.Symbol, symbol) {
+			return ch
+		}
+	}
+	if len(chains) > 0 {
+		return chains[0]
+	}
+	return nil
+}
+
+// expirations returns the expiration dates of chain selected by f.
+func (f ChainFilter) expirations(chain *OptionChain) ([]Date, error) {
+	var listed []Date
+	for _, s := range chain.ExpirationDates {
+		t, err := time.Parse(dateFormat, s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid expiration date %q", s)
+		}
+		listed = append(listed, Date{t})
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].Before(listed[j].Time) })
+
+	if len(f.Expirations) == 0 {
+		if f.MaxExpirations > 0 && len(listed) > f.MaxExpirations {
+			listed = listed[:f.MaxExpirations]
+		}
+		return listed, nil
+	}
+
+	out := make([]Date, 0, len(f.Expirations))
+	for _, d := range f.Expirations {
+		found := false
+		for _, l := range listed {
+			if l.String() == d.String() {
+				out = append(out, l)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no %s options expire on %s", chain.Symbol, d)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j].Time) })
+	return out, nil
+}
@@ -0,0 +1,137 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainSnapshot(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+
+	inst := srv.AddInstrument(robinhoodtest.Instrument{Symbol: "XYZ"})
+	chain := srv.AddOptionChain(robinhoodtest.OptionChain{Symbol: "XYZ", EquityInstrumentID: inst.ID})
+	add := func(exp, typ string, strike int) {
+		o := srv.AddOptionInstrument(robinhoodtest.OptionInstrument{ChainID: chain.ID, ExpirationDate: exp, Type: typ, StrikePrice: fmt.Sprint(strike)})
+		if strike == 110 {
+			return // no market data
+		}
+		srv.SetOptionMarketData(o.ID, robinhoodtest.OptionMarketData{
+			BidPrice:          fmt.Sprintf("%d.10", strike%7),
+			AskPrice:          fmt.Sprintf("%d.20", strike%7),
+			MarkPrice:         fmt.Sprintf("%d.15", strike%7),
+			ImpliedVolatility: "0.3",
+			Delta:             "0.5",
+			Gamma:             "0.01",
+		})
+	}
+	// 41 strikes on the first expiration, more than one batch of market
+	// data.
+	for k := 70; k <= 110; k++ {
+		add("2021-06-18", "call", k)
+		add("2021-06-18", "put", k)
+	}
+	for _, k := range []int{90, 95, 100, 105, 120} {
+		add("2021-06-25", "call", k)
+		add("2021-06-25", "put", k)
+		add("2021-07-16", "put", k)
+	}
+
+	s, err := c.ChainSnapshot(ctx, "XYZ", ChainFilter{MaxExpirations: 2})
+	require.NoError(t, err)
+	require.Equal(t, chain.ID, s.Chain.ID)
+	require.Equal(t, []Date{NewZonedDate(2021, 6, 18, time.UTC), NewZonedDate(2021, 6, 25, time.UTC)}, s.Expirations)
+	require.Len(t, s.Strikes, 42)
+	require.Equal(t, "70", s.Strikes[0].String())
+	require.Equal(t, "120", s.Strikes[41].String())
+	require.Len(t, s.Matrix, 42)
+	require.Len(t, s.Matrix[0], 2)
+
+	cell, ok := s.At(NewDecimal(100, 0), NewDate(2021, 6, 25))
+	require.True(t, ok)
+	require.Equal(t, "call", cell.Call.Option.Type)
+	require.Equal(t, "put", cell.Put.Option.Type)
+	require.Equal(t, cell.Call.Option.URL, cell.Call.Quote.Instrument)
+	require.Equal(t, "2.1", cell.Call.Quote.BidPrice.String())
+	require.Equal(t, "2.2", cell.Put.Quote.AskPrice.String())
+	require.Equal(t, "0.3", cell.Put.Quote.ImpliedVolatility)
+	require.Equal(t, 0.5, *cell.Put.Quote.Delta)
+
+	cell, ok = s.At(NewDecimal(71, 0), NewDate(2021, 6, 25))
+	require.True(t, ok)
+	require.Nil(t, cell.Call)
+	require.Nil(t, cell.Put)
+	cell, ok = s.At(NewDecimal(110, 0), NewDate(2021, 6, 18))
+	require.True(t, ok)
+	require.NotNil(t, cell.Call)
+	require.Nil(t, cell.Call.Quote)
+	_, ok = s.At(NewDecimal(115, 0), NewDate(2021, 6, 18))
+	require.False(t, ok)
+	_, ok = s.At(NewDecimal(100, 0), NewDate(2021, 7, 16))
+	require.False(t, ok)
+
+	s, err = c.ChainSnapshot(ctx, "XYZ", ChainFilter{
+		Expirations: []Date{NewDate(2021, 7, 16), NewDate(2021, 6, 18)},
+		MinStrike:   NewDecimal(95, 0),
+		MaxStrike:   NewDecimal(105, 0),
+		Type:        "put",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Date{NewZonedDate(2021, 6, 18, time.UTC), NewZonedDate(2021, 7, 16, time.UTC)}, s.Expirations)
+	require.Len(t, s.Strikes, 11)
+	cell, ok = s.At(NewDecimal(95, 0), NewDate(2021, 7, 16))
+	require.True(t, ok)
+	require.Nil(t, cell.Call)
+	require.Equal(t, "4.15", cell.Put.Quote.MarkPrice.String())
+
+	_, err = c.ChainSnapshot(ctx, "XYZ", ChainFilter{Expirations: []Date{NewDate(2021, 6, 19)}})
+	require.EqualError(t, err, "no XYZ options expire on 2021-06-19")
+
+	for _, n := range []int{1, 0} {
+		rt := &inflightTransport{}
+		bounded := dialTest(t, srv, WithTransport(rt))
+		_, err = bounded.ChainSnapshot(ctx, "XYZ", ChainFilter{Concurrency: n})
+		require.NoError(t, err)
+		if n == 0 {
+			n = DefaultChainConcurrency
+		}
+		require.True(t, rt.max <= n, "%d requests in flight, want at most %d", rt.max, n)
+	}
+
+	srv.AddFault(robinhoodtest.Fault{Path: "/marketdata/options/", Status: http.StatusBadRequest})
+	_, err = c.ChainSnapshot(ctx, "XYZ", ChainFilter{Type: "call", MaxExpirations: 1})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error getting calls expiring 2021-06-18")
+}
+
+// inflightTransport records the most requests it has had in flight at
+// once.
+type inflightTransport struct {
+	mu       sync.Mutex
+	cur, max int
+}
+
+func (t *inflightTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.cur++
+	if t.cur > t.max {
+		t.max = t.cur
+	}
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.cur--
+		t.mu.Unlock()
+	}()
+	time.Sleep(time.Millisecond)
+	return http.DefaultTransport.RoundTrip(req)
+}
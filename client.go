@@ -1,17 +1,15 @@
 package robinhood
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
-	"golang.org/x/oauth2"
 )
 
 // Endpoints for the Robinhood API
@@ -43,34 +41,52 @@ type Client struct {
 	Token         string
 	Account       *Account
 	CryptoAccount *CryptoAccount
-	Debug         bool
-	*http.Client
-}
 
-// Dial returns a client given a TokenGetter. TokenGetter implementations are
-// available in this package, including a Cookie-based cache.
-func Dial(ctx context.Context, s oauth2.TokenSource) (*Client, error) {
-	c := &Client{
-		Client: oauth2.NewClient(context.Background(), s),
-	}
+	// Debug logs every request and response with a default LogHook if no
+	// Hook is set.
+	//
+	// Deprecated: set Hook instead.
+	Debug bool
 
-	a, err := c.GetAccounts(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "error getting accounts")
-	}
-	if len(a) > 0 {
-		c.Account = &a[0]
-	}
+	// Hook, if set, observes every request and response.
+	Hook Hook
 
-	ca, err := c.GetCryptoAccounts(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "error getting crypto accounts")
-	}
+	// Retry decides which failed requests are retried. Only idempotent
+	// requests (see IdempotencyKeyHeader) are ever retried; a nil Retry
+	// disables retries.
+	Retry RetryPolicy
 
-	if len(ca) > 0 {
-		c.CryptoAccount = &ca[0]
-	}
-	return c, err
+	// RetryOrders lets Retry retry order placements, which are otherwise
+	// sent once, by sending each order's ref_id as its idempotency key.
+	// This is only safe if the API creates no more than one order per
+	// ref_id, which the client has no way to check. Without it, use the
+	// FindOrderByRefID methods to learn the outcome of an order that
+	// failed in transit.
+	RetryOrders bool
+
+	// Limiter, if set, is waited on before every request, including
+	// retries.
+	Limiter RateLimiter
+
+	// BaseURL and CryptoBaseURL replace EPBase and EPCryptoBase respectively
+	// for every request made through the client, e.g. to point it at an
+	// httptest server or a staging proxy. Empty values use the real API.
+	BaseURL, CryptoBaseURL string
+
+	// Decode selects how strictly responses are decoded.
+	Decode DecodeMode
+
+	// OnDecodeWarning, if set, is called for every unknown field and every
+	// value that does not match its field's type in a response.
+	OnDecodeWarning func(DecodeWarning)
+
+	// Ticks decides whether order prices and quantities that are not a
+	// multiple of their increment are rounded or rejected.
+	Ticks TickPolicy
+
+	// UserAgent, if set, is sent with every request.
+	UserAgent string
+	*http.Client
 }
 
 // GetAndDecode retrieves from the endpoint and unmarshals resulting json into
@@ -84,6 +100,9 @@ func (c *Client) GetAndDecode(ctx context.Context, url string, dest interface{})
 }
 
 // ErrorMap encapsulates the helpful error messages returned by the API server
+//
+// Deprecated: DoAndDecode returns an *APIError, which carries the same
+// messages along with the response status.
 type ErrorMap map[string]interface{}
 
 func (e ErrorMap) Error() string {
@@ -91,42 +110,66 @@ func (e ErrorMap) Error() string {
 	for k, v := range e {
 		es = append(es, fmt.Sprintf("%s: %q", k, v))
 	}
+	sort.Strings(es)
 	return "Error returned from API: " + strings.Join(es, ", ")
 }
 
 // DoAndDecode provides useful abstractions around common errors and decoding
-// issues.
+// issues. Responses with a status of 400 or above are returned as an
+// *APIError.
 func (c *Client) DoAndDecode(ctx context.Context, req *http.Request, dest interface{}) error {
-	res, err := c.Do(req.WithContext(ctx))
+	req = req.WithContext(ctx)
+	if u := c.rebase(req.URL.String()); u != req.URL.String() {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return errors.Wrap(err, "error rebasing request URL")
+		}
+		req.URL = parsed
+		req.Host = parsed.Host
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	res, data, err := c.do(req)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
-
 	if res.StatusCode >= 400 {
-		b := &bytes.Buffer{}
-		var e ErrorMap
-		err = json.NewDecoder(io.TeeReader(res.Body, b)).Decode(&e)
-		if err != nil {
-			return fmt.Errorf(
-				"got response %q and could not decode error body %q",
-				res.Status,
-				b.String(),
-			)
-		}
-		return e
+		return newAPIError(req, res, data)
 	}
-	data := &bytes.Buffer{}
-	if _, err := io.Copy(data, res.Body); err != nil {
-		return errors.Wrap(err, "error copying data into buffer")
+	return c.decode(req.URL.String(), data, dest)
+}
+
+// hook returns the Hook requests should be reported to, if any.
+func (c *Client) hook() Hook {
+	if c.Hook == nil && c.Debug {
+		return &LogHook{}
 	}
-	if err := json.Unmarshal(data.Bytes(), dest); err != nil {
-		if c.Debug {
-			fmt.Println(string(data.Bytes()))
-		}
-		return errors.Wrap(err, "error decoding the response")
+	return c.Hook
+}
+
+// rebase rewrites a URL rooted at one of the production endpoints onto the
+// client's configured BaseURL or CryptoBaseURL.
+func (c *Client) rebase(u string) string {
+	return rebaseURL(u, c.BaseURL, c.CryptoBaseURL)
+}
+
+func rebaseURL(u, base, cryptoBase string) string {
+	switch {
+	case base != "" && strings.HasPrefix(u, EPBase):
+		return withTrailingSlash(base) + strings.TrimPrefix(u, EPBase)
+	case cryptoBase != "" && strings.HasPrefix(u, EPCryptoBase):
+		return withTrailingSlash(cryptoBase) + strings.TrimPrefix(u, EPCryptoBase)
+	}
+	return u
+}
+
+func withTrailingSlash(s string) string {
+	if strings.HasSuffix(s, "/") {
+		return s
 	}
-	return nil
+	return s + "/"
 }
 
 // Meta holds metadata common to many RobinHood types.
@@ -0,0 +1,58 @@
+package robinhood
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestDialWithBaseURLs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": [{"account_number": "5RY82436"}]}`))
+	})
+	mux.HandleFunc("/nummus/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results": [{"id": "crypto-1"}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+	c, err := Dial(context.Background(), ts, WithBaseURLs(srv.URL, srv.URL+"/nummus"))
+	require.NoError(t, err)
+	require.Equal(t, "5RY82436", c.Account.AccountNumber)
+	require.Equal(t, "crypto-1", c.CryptoAccount.ID)
+}
+
+func TestRebaseURL(t *testing.T) {
+	require.Equal(t, "http://localhost/orders/", rebaseURL(EPOrders, "http://localhost", ""))
+	require.Equal(t, "http://localhost/n/orders/", rebaseURL(EPCryptoOrders, "", "http://localhost/n/"))
+	require.Equal(t, EPCryptoOrders, rebaseURL(EPCryptoOrders, "http://localhost", ""))
+	require.Equal(t, "https://example.com/x/", rebaseURL("https://example.com/x/", "http://localhost", "http://localhost"))
+}
+
+func dialTest(t *testing.T, srv *robinhoodtest.Server, opts ...DialOption) *Client {
+	c, err := dialTestErr(srv, opts...)
+	require.NoError(t, err)
+	return c
+}
+
+func countRequests(srv *robinhoodtest.Server, method, path string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == method && r.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+func dialTestErr(srv *robinhoodtest.Server, opts ...DialOption) (*Client, error) {
+	opts = append([]DialOption{WithBaseURLs(srv.URL(), srv.CryptoURL())}, opts...)
+	return Dial(context.Background(), srv.TokenSource(), opts...)
+}
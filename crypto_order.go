@@ -1,51 +1,46 @@
 package robinhood
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"math"
 
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
-	"encoding/json"
-
 	"net/http"
 )
 
 // CryptoOrder is the payload to create a crypto currency order
 type CryptoOrder struct {
-	AccountID      string  `json:"account_id,omitempty"`
-	CurrencyPairID string  `json:"currency_pair_id,omitempty"`
-	Price          float64 `json:"price,omitempty"`
-	RefID          string  `json:"ref_id,omitempty"`
-	Side           string  `json:"side,omitempty"`
-	TimeInForce    string  `json:"time_in_force,omitempty"`
-	Quantity       float64 `json:"quantity,omitempty"`
-	Type           string  `json:"type,omitempty"`
+	AccountID      string   `json:"account_id,omitempty"`
+	CurrencyPairID string   `json:"currency_pair_id,omitempty"`
+	Price          *Decimal `json:"price,omitempty"`
+	RefID          string   `json:"ref_id,omitempty"`
+	Side           string   `json:"side,omitempty"`
+	TimeInForce    string   `json:"time_in_force,omitempty"`
+	Quantity       *Decimal `json:"quantity,omitempty"`
+	Type           string   `json:"type,omitempty"`
 }
 
 // CryptoOrderOutput holds the response from api
 type CryptoOrderOutput struct {
 	Meta
-	Account            string        `json:"account"`
-	AveragePrice       float64       `json:"average_price,string"`
-	CancelURL          string        `json:"cancel"`
-	CreatedAt          string        `json:"created_at"`
-	CumulativeQuantity string        `json:"cumulative_quantity"`
-	CurrencyPairID     string        `json:"currency_pair_id"`
-	Executions         []interface{} `json:"executions"`
-	ID                 string        `json:"id"`
-	LastTransactionAt  string        `json:"last_transaction_at"`
-	Price              float64       `json:"price,string"`
-	Quantity           string        `json:"quantity"`
-	RejectReason       string        `json:"reject_reason"`
-	Side               string        `json:"side"`
-	State              string        `json:"state"`
-	StopPrice          float64       `json:"stop_price,string"`
-	TimeInForce        string        `json:"time_in_force"`
-	Type               string        `json:"type"`
+	Account            string      `json:"account"`
+	AveragePrice       Decimal     `json:"average_price"`
+	CancelURL          string      `json:"cancel"`
+	CreatedAt          string      `json:"created_at"`
+	CumulativeQuantity Decimal     `json:"cumulative_quantity"`
+	CurrencyPairID     string      `json:"currency_pair_id"`
+	Executions         []Execution `json:"executions"`
+	ID                 string      `json:"id"`
+	LastTransactionAt  string      `json:"last_transaction_at"`
+	Price              Decimal     `json:"price"`
+	Quantity           Decimal     `json:"quantity"`
+	RefID              string      `json:"ref_id"`
+	RejectReason       string      `json:"reject_reason"`
+	Side               OrderSide   `json:"side"`
+	State              OrderState  `json:"state"`
+	StopPrice          Decimal     `json:"stop_price"`
+	TimeInForce        string      `json:"time_in_force"`
+	Type               OrderType   `json:"type"`
 
 	client *Client
 }
@@ -54,47 +49,69 @@ type CryptoOrderOutput struct {
 type CryptoOrderOpts struct {
 	Side            OrderSide
 	Type            OrderType
-	AmountInDollars float64
-	Quantity        float64
-	Price           float64
+	AmountInDollars Decimal
+	Quantity        Decimal
+	Price           Decimal
 	TimeInForce     TimeInForce
 	ExtendedHours   bool
 	Stop, Force     bool
+
+	// RefID identifies the order; see NewRefID. If empty, a new one is
+	// generated.
+	RefID string
 }
 
 // CryptoOrder will actually place the order
 func (c *Client) CryptoOrder(ctx context.Context, cryptoPair CryptoCurrencyPair, o CryptoOrderOpts) (*CryptoOrderOutput, error) {
-	var quantity = math.Round(o.AmountInDollars / o.Price)
+	if c.CryptoAccount == nil {
+		return nil, errors.New("no crypto account")
+	}
+	price, err := c.checkPrice(o.Price, cryptoPair.MinOrderPriceIncrement, o.Type == Limit || o.Stop)
+	if err != nil {
+		return nil, err
+	}
+	quantity := o.Quantity
+	if quantity.IsZero() && !price.IsZero() {
+		// Buy as much as AmountInDollars pays for, in whole units of the
+		// asset's increment.
+		inc := cryptoPair.CyrptoAssetCurrency.Increment
+		if inc.IsZero() {
+			inc = NewDecimal(1, 0)
+		}
+		quantity = o.AmountInDollars.QuoTrunc(price, inc.scale).TruncateToTick(inc)
+	}
+	if quantity, err = c.checkQuantity(quantity, cryptoPair.CyrptoAssetCurrency.Increment); err != nil {
+		return nil, err
+	}
+	if err := checkOrderSize(quantity, cryptoPair); err != nil {
+		return nil, err
+	}
 	a := CryptoOrder{
 		AccountID:      c.CryptoAccount.ID,
 		CurrencyPairID: cryptoPair.ID,
-		Quantity:       quantity,
-		Price:          o.Price,
-		RefID:          uuid.New().String(),
+		Quantity:       decimalPtr(quantity),
+		Price:          decimalPtr(price),
+		RefID:          refID(o.RefID),
 		Side:           o.Side.String(),
 		TimeInForce:    o.TimeInForce.String(),
 		Type:           o.Type.String(),
 	}
 
-	payload, err := json.Marshal(a)
-
-	if err != nil {
-		return nil, err
-	}
-
-	post, err := http.NewRequest("POST", EPCryptoOrders, bytes.NewReader(payload))
-	if err != nil {
-		return nil, fmt.Errorf("could not create Crypto http.Request: %w", err)
-	}
-
-	post.Header.Add("Content-Type", "application/json")
-
 	var out CryptoOrderOutput
-	err = c.DoAndDecode(ctx, post, &out)
+	err = c.postOrder(ctx, EPCryptoOrders, a.RefID, a, &out)
 	out.client = c
 	return &out, err
 }
 
+// Update returns any errors and updates the item with any recent changes.
+func (o *CryptoOrderOutput) Update(ctx context.Context) error {
+	url := o.URL
+	if url == "" {
+		url = EPCryptoOrders + o.ID + "/"
+	}
+	return o.client.GetAndDecode(ctx, url, o)
+}
+
 // Cancel will cancel the order.
 func (o CryptoOrderOutput) Cancel(ctx context.Context) error {
 	post, err := http.NewRequest("POST", o.CancelURL, nil)
@@ -11,9 +11,9 @@ import (
 type CryptoCurrencyPair struct {
 	CyrptoAssetCurrency    AssetCurrency `json:"asset_currency"`
 	ID                     string        `json:"id"`
-	MaxOrderSize           float64       `json:"max_order_size,string"`
-	MinOrderPriceIncrement float64       `json:"min_order_price_increment,string"`
-	MinOrderSize           float64       `json:"min_order_size,string"`
+	MaxOrderSize           Decimal       `json:"max_order_size"`
+	MinOrderPriceIncrement Decimal       `json:"min_order_price_increment"`
+	MinOrderSize           Decimal       `json:"min_order_size"`
 	Name                   string        `json:"name"`
 	CrytoQuoteCurrency     QuoteCurrency `json:"quote_currency"`
 	Symbol                 string        `json:"symbol"`
@@ -24,7 +24,7 @@ type CryptoCurrencyPair struct {
 type QuoteCurrency struct {
 	Code      string  `json:"code"`
 	ID        string  `json:"id"`
-	Increment float64 `json:"increment,string"`
+	Increment Decimal `json:"increment"`
 	Name      string  `json:"name"`
 	Type      string  `json:"type"`
 }
@@ -34,15 +34,15 @@ type AssetCurrency struct {
 	BrandColor string  `json:"brand_color"`
 	Code       string  `json:"code"`
 	ID         string  `json:"id"`
-	Increment  float64 `json:"increment,string"`
+	Increment  Decimal `json:"increment"`
 	Name       string  `json:"name"`
 }
 
 // GetCryptoCurrencyPairs will give which crypto currencies are tradeable and corresponding ids
 func (c *Client) GetCryptoCurrencyPairs(ctx context.Context) ([]CryptoCurrencyPair, error) {
-	var r struct{ Results []CryptoCurrencyPair }
-	err := c.GetAndDecode(ctx, EPCryptoCurrencyPairs, &r)
-	return r.Results, err
+	var ps []CryptoCurrencyPair
+	err := c.getAll(ctx, EPCryptoCurrencyPairs, &ps)
+	return ps, err
 }
 
 // GetCryptoInstrument will take standard crypto symbol and return usable information
@@ -0,0 +1,314 @@
+package robinhood
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// A Decimal is an exact decimal number, used for prices, quantities and
+// balances. The zero value is 0. Decimals are immutable, and are kept without
+// trailing zeros so that equal values are also equal to reflect.DeepEqual.
+//
+// Decimals are marshalled to JSON as strings, as the API sends them, and
+// unmarshalled from strings, numbers, null or the empty string.
+type Decimal struct {
+	unscaled *big.Int // nil for zero
+	scale    int32    // digits after the decimal point, never negative
+}
+
+var (
+	bigTen = big.NewInt(10)
+	bigOne = big.NewInt(1)
+)
+
+// NewDecimal returns the Decimal unscaled × 10^-scale, e.g. NewDecimal(12345,
+// 2) is 123.45.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return newDecimal(big.NewInt(unscaled), scale)
+}
+
+// DecimalFromFloat returns the Decimal with the shortest representation that
+// rounds to f, so that DecimalFromFloat(0.3) is exactly 0.3. It panics if f
+// is NaN or infinite.
+func DecimalFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(fmt.Sprintf("robinhood: DecimalFromFloat(%v)", f))
+	}
+	return MustParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// ParseDecimal parses a decimal number such as "-12.340".
+func ParseDecimal(s string) (Decimal, error) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	var scale int32
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		scale = int32(len(digits) - i - 1)
+		digits = digits[:i] + digits[i+1:]
+	}
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	i, _ := new(big.Int).SetString(digits, 10)
+	if strings.HasPrefix(s, "-") {
+		i.Neg(i)
+	}
+	return newDecimal(i, scale), nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s cannot be parsed. It
+// is intended for constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic("robinhood: " + err.Error())
+	}
+	return d
+}
+
+// newDecimal returns i × 10^-scale in canonical form. It takes ownership of i.
+func newDecimal(i *big.Int, scale int32) Decimal {
+	if i.Sign() == 0 {
+		return Decimal{}
+	}
+	for ; scale < 0; scale++ {
+		i.Mul(i, bigTen)
+	}
+	q, r := new(big.Int), new(big.Int)
+	for scale > 0 {
+		q.QuoRem(i, bigTen, r)
+		if r.Sign() != 0 {
+			break
+		}
+		i, q = q, i
+		scale--
+	}
+	return Decimal{unscaled: i, scale: scale}
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(d.unscaled)
+}
+
+// rescaled returns the unscaled value of d at the given scale, which must be
+// at least d.scale.
+func (d Decimal) rescaled(scale int32) *big.Int {
+	i := d.int()
+	if scale > d.scale {
+		i.Mul(i, pow10(scale-d.scale))
+	}
+	return i
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func maxScale(a, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	s := maxScale(d, e)
+	return newDecimal(d.rescaled(s).Add(d.rescaled(s), e.rescaled(s)), s)
+}
+
+// Sub returns d - e.
+func (d Decimal) Sub(e Decimal) Decimal {
+	return d.Add(e.Neg())
+}
+
+// Mul returns d × e.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return newDecimal(d.int().Mul(d.int(), e.int()), d.scale+e.scale)
+}
+
+// Quo returns d / e rounded half away from zero to scale digits after the
+// decimal point. It panics if e is zero.
+func (d Decimal) Quo(e Decimal, scale int32) Decimal {
+	if e.IsZero() {
+		panic("robinhood: division of Decimal by zero")
+	}
+	// d/e = (dU × 10^-ds) / (eU × 10^-es); compute at scale+1 digits and
+	// round the last one away.
+	num := d.int().Mul(d.int(), pow10(scale+1+e.scale))
+	den := e.int().Mul(e.int(), pow10(d.scale))
+	q := new(big.Int).Quo(num, den)
+	return roundLastDigit(q, scale)
+}
+
+// QuoTrunc returns d / e truncated towards zero to scale digits after the
+// decimal point. It panics if e is zero.
+func (d Decimal) QuoTrunc(e Decimal, scale int32) Decimal {
+	if e.IsZero() {
+		panic("robinhood: division of Decimal by zero")
+	}
+	num := d.int().Mul(d.int(), pow10(scale+e.scale))
+	den := e.int().Mul(e.int(), pow10(d.scale))
+	return newDecimal(num.Quo(num, den), scale)
+}
+
+// roundLastDigit drops the last digit of i, rounding half away from zero,
+// and returns the result at the given scale.
+func roundLastDigit(i *big.Int, scale int32) Decimal {
+	q, r := new(big.Int).QuoRem(i, bigTen, new(big.Int))
+	if r.CmpAbs(big.NewInt(5)) >= 0 {
+		if i.Sign() < 0 {
+			q.Sub(q, bigOne)
+		} else {
+			q.Add(q, bigOne)
+		}
+	}
+	return newDecimal(q, scale)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	i := d.int()
+	return newDecimal(i.Neg(i), d.scale)
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	if d.Sign() < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+// Sign returns -1, 0 or 1 as d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	if d.unscaled == nil {
+		return 0
+	}
+	return d.unscaled.Sign()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1, 0 or 1 as d is less than, equal to or greater than e.
+func (d Decimal) Cmp(e Decimal) int {
+	s := maxScale(d, e)
+	return d.rescaled(s).Cmp(e.rescaled(s))
+}
+
+// Equal reports whether d and e are the same number.
+func (d Decimal) Equal(e Decimal) bool {
+	return d.Cmp(e) == 0
+}
+
+// Round returns d rounded half away from zero to places digits after the
+// decimal point.
+func (d Decimal) Round(places int32) Decimal {
+	if d.scale <= places {
+		return d
+	}
+	i := d.int()
+	i.Quo(i, pow10(d.scale-places-1))
+	return roundLastDigit(i, places)
+}
+
+// Truncate returns d rounded towards zero to places digits after the decimal
+// point.
+func (d Decimal) Truncate(places int32) Decimal {
+	if d.scale <= places {
+		return d
+	}
+	i := d.int()
+	return newDecimal(i.Quo(i, pow10(d.scale-places)), places)
+}
+
+// RoundToTick returns the multiple of tick nearest to d, rounding half away
+// from zero, e.g. 1.234 rounded to a tick of 0.05 is 1.25. A zero tick
+// returns d unchanged.
+func (d Decimal) RoundToTick(tick Decimal) Decimal {
+	if tick.IsZero() {
+		return d
+	}
+	tick = tick.Abs()
+	return d.Quo(tick, 0).Mul(tick)
+}
+
+// TruncateToTick returns the multiple of tick nearest to d in the direction
+// of zero, e.g. 1.249 truncated to a tick of 0.05 is 1.2. A zero tick
+// returns d unchanged.
+func (d Decimal) TruncateToTick(tick Decimal) Decimal {
+	if tick.IsZero() {
+		return d
+	}
+	tick = tick.Abs()
+	return d.QuoTrunc(tick, 0).Mul(tick)
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d in plain decimal notation, e.g. "-0.05".
+func (d Decimal) String() string {
+	if d.unscaled == nil {
+		return "0"
+	}
+	s := new(big.Int).Abs(d.unscaled).String()
+	if d.scale > 0 {
+		if pad := int(d.scale) - len(s) + 1; pad > 0 {
+			s = strings.Repeat("0", pad) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if d.unscaled.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// MarshalJSON implements json.Marshaler
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Decimal) UnmarshalJSON(bs []byte) error {
+	bs = bytes.TrimSpace(bs)
+	if string(bs) == "null" {
+		return nil
+	}
+	s := strings.Trim(string(bs), `"`)
+	if s == "" {
+		*d = Decimal{}
+		return nil
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// decimalPtr returns a pointer to d, or nil if d is zero, for optional
+// request fields.
+func decimalPtr(d Decimal) *Decimal {
+	if d.IsZero() {
+		return nil
+	}
+	return &d
+}
@@ -0,0 +1,103 @@
+package robinhood
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for s, want := range map[string]string{
+		"0":                    "0",
+		"-0.00":                "0",
+		"400.0100":             "400.01",
+		"+1.5":                 "1.5",
+		"-0.05":                "-0.05",
+		"0.000000001":          "0.000000001",
+		"12":                   "12",
+		".5":                   "0.5",
+		"90.00000000000000000": "90",
+	} {
+		d, err := ParseDecimal(s)
+		require.NoError(t, err, s)
+		require.Equal(t, want, d.String(), s)
+	}
+	for _, s := range []string{"", "-", ".", "1e5", "1.2.3", "--1", "abc"} {
+		_, err := ParseDecimal(s)
+		require.Error(t, err, s)
+	}
+	require.Equal(t, MustParseDecimal("1.50"), NewDecimal(15, 1))
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := DecimalFromFloat(0.1), DecimalFromFloat(0.2)
+	require.Equal(t, "0.3", a.Add(b).String())
+	require.Equal(t, MustParseDecimal("0.3"), a.Add(b))
+	require.Equal(t, "-0.1", a.Sub(b).String())
+	require.Equal(t, "0.02", a.Mul(b).String())
+	require.Equal(t, "0.3333", a.Quo(MustParseDecimal("0.3"), 4).String())
+	require.Equal(t, "-0.6667", NewDecimal(-2, 0).Quo(NewDecimal(3, 0), 4).String())
+	require.Equal(t, 1, b.Cmp(a))
+	require.True(t, b.Neg().Abs().Equal(b))
+	require.Equal(t, 0.3, a.Add(b).Float64())
+	require.Panics(t, func() { a.Quo(Decimal{}, 2) })
+}
+
+func TestDecimalRounding(t *testing.T) {
+	d := MustParseDecimal("1.2345")
+	require.Equal(t, "1.235", d.Round(3).String())
+	require.Equal(t, "1.23", d.Round(2).String())
+	require.Equal(t, "-1.235", d.Neg().Round(3).String())
+	require.Equal(t, "1.234", d.Truncate(3).String())
+	require.Equal(t, "1.2345", d.Round(6).String())
+
+	tick := MustParseDecimal("0.05")
+	require.Equal(t, "1.25", MustParseDecimal("1.234").RoundToTick(tick).String())
+	require.Equal(t, "1.2", MustParseDecimal("1.2249").RoundToTick(tick).String())
+	require.Equal(t, "1.25", MustParseDecimal("1.225").RoundToTick(tick).String())
+	require.Equal(t, "-1.25", MustParseDecimal("-1.225").RoundToTick(tick).String())
+	require.Equal(t, "1.225", MustParseDecimal("1.225").RoundToTick(Decimal{}).String())
+
+	require.Equal(t, "1.2", MustParseDecimal("1.249").TruncateToTick(tick).String())
+	require.Equal(t, "1.25", MustParseDecimal("1.25").TruncateToTick(tick).String())
+	require.Equal(t, "-1.2", MustParseDecimal("-1.249").TruncateToTick(tick).String())
+	require.Equal(t, "0.6666", NewDecimal(2, 0).QuoTrunc(NewDecimal(3, 0), 4).String())
+	require.Equal(t, "-0.6666", NewDecimal(-2, 0).QuoTrunc(NewDecimal(3, 0), 4).String())
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		A, B, C, D, E Decimal
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"A": "1.10", "B": 2.5, "C": null, "D": "", "E": "-3"}`), &v))
+	require.Equal(t, "1.1", v.A.String())
+	require.Equal(t, "2.5", v.B.String())
+	require.True(t, v.C.IsZero())
+	require.True(t, v.D.IsZero())
+	require.Equal(t, "-3", v.E.String())
+
+	bs, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.Equal(t, `{"A":"1.1","B":"2.5","C":"0","D":"0","E":"-3"}`, string(bs))
+
+	require.Error(t, json.Unmarshal([]byte(`{"A": "x"}`), &v))
+}
+
+func TestOrderPriceIsExact(t *testing.T) {
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+
+	price := DecimalFromFloat(0.1).Add(DecimalFromFloat(0.2))
+	out, err := c.Order(context.Background(), &Instrument{URL: srv.URL() + "instruments/x/"}, OrderOpts{
+		Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: price,
+	})
+	require.NoError(t, err)
+	require.Equal(t, price, out.Price)
+	ord, ok := srv.Order(out.ID)
+	require.True(t, ok)
+	require.Equal(t, "0.3", ord["price"])
+}
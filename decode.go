@@ -0,0 +1,363 @@
+package robinhood
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DecodeMode selects how strictly the client decodes API responses.
+type DecodeMode int
+
+// Decode modes.
+const (
+	// DecodeStrict decodes responses with encoding/json as is, so that a
+	// single malformed field fails the whole request.
+	DecodeStrict DecodeMode = iota
+
+	// DecodeLenient leaves fields that cannot be decoded at their zero
+	// value instead of failing. Null and empty strings are accepted for
+	// numeric fields, and numbers sent as strings (or strings sent as
+	// numbers) are converted where possible.
+	DecodeLenient
+)
+
+// A DecodeWarning describes a response field that did not match the type it
+// was decoded into.
+type DecodeWarning struct {
+	// URL is the URL of the request the response belongs to.
+	URL string
+	// Path is the JSON path of the field, e.g. "results[2].stop_price".
+	Path string
+	// Type is the Go type the field is decoded into, e.g. "float64". It is
+	// empty for unknown fields.
+	Type string
+	// Value is the raw JSON value of the field.
+	Value string
+}
+
+// Unknown reports whether the field has no counterpart in the Go type.
+func (w DecodeWarning) Unknown() bool {
+	return w.Type == ""
+}
+
+func (w DecodeWarning) String() string {
+	if w.Unknown() {
+		return fmt.Sprintf("%s: unknown field %s = %s", w.URL, w.Path, w.Value)
+	}
+	return fmt.Sprintf("%s: cannot decode %s = %s into %s", w.URL, w.Path, w.Value, w.Type)
+}
+
+// decode unmarshals a response body from url into dest according to the
+// client's DecodeMode, reporting warnings to OnDecodeWarning.
+func (c *Client) decode(url string, data []byte, dest interface{}) error {
+	// Only a non-nil pointer can be normalized; encoding/json reports the
+	// error for anything else.
+	if rv := reflect.ValueOf(dest); rv.Kind() != reflect.Ptr || rv.IsNil() ||
+		c.Decode == DecodeStrict && c.OnDecodeWarning == nil {
+		return errors.Wrap(json.Unmarshal(data, dest), "error decoding the response")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return errors.Wrap(err, "error decoding the response")
+	}
+	n := &normalizer{
+		url:     url,
+		lenient: c.Decode == DecodeLenient,
+		warn:    c.OnDecodeWarning,
+	}
+	x, keep := n.normalize(v, reflect.TypeOf(dest), "", false)
+	if n.lenient && keep {
+		bs, err := json.Marshal(x)
+		if err != nil {
+			return errors.Wrap(err, "error decoding the response")
+		}
+		data = bs
+	}
+	return errors.Wrap(json.Unmarshal(data, dest), "error decoding the response")
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	jsonNumberType      = reflect.TypeOf(json.Number(""))
+)
+
+// normalizer walks a decoded JSON value alongside the Go type it is to be
+// unmarshalled into, reporting unknown fields and mismatched values. When
+// lenient, mismatched values are converted or dropped so that encoding/json
+// accepts the result.
+type normalizer struct {
+	url     string
+	lenient bool
+	warn    func(DecodeWarning)
+}
+
+func (n *normalizer) report(path string, t reflect.Type, v interface{}) {
+	if n.warn == nil {
+		return
+	}
+	w := DecodeWarning{URL: n.url, Path: path, Value: rawJSON(v)}
+	if t != nil {
+		w.Type = t.String()
+	}
+	n.warn(w)
+}
+
+// normalize returns v adjusted for t, and false if v should be dropped.
+// quoted is set for fields with the ",string" option.
+func (n *normalizer) normalize(v interface{}, t reflect.Type, path string, quoted bool) (interface{}, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if v == nil && !reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return nil, true
+	}
+
+	switch {
+	case t == jsonNumberType:
+		return n.scalar(v, t, path, false)
+	case reflect.PtrTo(t).Implements(jsonUnmarshalerType):
+		bs, err := json.Marshal(v)
+		if err == nil {
+			err = json.Unmarshal(bs, reflect.New(t).Interface())
+		}
+		if err == nil {
+			return v, true
+		}
+		if v != nil && v != "" {
+			n.report(path, t, v)
+		}
+		return v, !n.lenient
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return v, true
+	case reflect.Struct:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			if v != "" {
+				n.report(path, t, v)
+			}
+			return v, !n.lenient
+		}
+		fields := jsonFields(t)
+		for _, k := range sortedKeys(m) {
+			f, ok := fields[k]
+			if !ok {
+				for name, ff := range fields {
+					if strings.EqualFold(name, k) {
+						f, ok = ff, true
+						break
+					}
+				}
+			}
+			p := joinPath(path, k)
+			if !ok {
+				n.report(p, nil, m[k])
+				continue
+			}
+			x, keep := n.normalize(m[k], f.typ, p, f.quoted)
+			if !keep {
+				delete(m, k)
+			} else {
+				m[k] = x
+			}
+		}
+		return m, true
+	case reflect.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			n.report(path, t, v)
+			return v, !n.lenient
+		}
+		for _, k := range sortedKeys(m) {
+			x, keep := n.normalize(m[k], t.Elem(), joinPath(path, k), false)
+			if !keep {
+				delete(m, k)
+			} else {
+				m[k] = x
+			}
+		}
+		return m, true
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return n.scalar(v, reflect.TypeOf(""), path, false)
+		}
+		s, ok := v.([]interface{})
+		if !ok {
+			n.report(path, t, v)
+			return v, !n.lenient
+		}
+		for i := range s {
+			x, keep := n.normalize(s[i], t.Elem(), fmt.Sprintf("%s[%d]", path, i), false)
+			if !keep {
+				x = nil
+			}
+			s[i] = x
+		}
+		return s, true
+	}
+	return n.scalar(v, t, path, quoted)
+}
+
+// scalar normalizes a JSON string, number or bool for a basic type t.
+// Values of the wrong JSON type are reported, and converted when lenient.
+func (n *normalizer) scalar(v interface{}, t reflect.Type, path string, quoted bool) (interface{}, bool) {
+	if v == "" && (quoted || t.Kind() != reflect.String) {
+		return v, !n.lenient
+	}
+
+	// With the ",string" option the value should be a JSON string holding
+	// the JSON literal for t.
+	raw, mismatch := v, false
+	if s, ok := v.(string); quoted && ok {
+		raw = nil
+		if err := json.Unmarshal([]byte(s), &raw); err != nil || t.Kind() != reflect.String {
+			raw = json.Number(s)
+		}
+		if t.Kind() == reflect.Bool && (s == "true" || s == "false") {
+			raw = s == "true"
+		}
+	} else if quoted {
+		mismatch = true
+	}
+
+	lit, ok := scalarFor(raw, t)
+	if !ok {
+		n.report(path, t, v)
+		return v, !n.lenient
+	}
+	if mismatch || reflect.TypeOf(lit) != reflect.TypeOf(raw) {
+		n.report(path, t, v)
+	}
+	if !n.lenient {
+		return v, true
+	}
+	if quoted {
+		bs, _ := json.Marshal(lit)
+		return string(bs), true
+	}
+	return lit, true
+}
+
+// scalarFor converts v to the JSON value encoding/json expects for t, if
+// possible without loss. The result is a string, json.Number or bool.
+func scalarFor(v interface{}, t reflect.Type) (interface{}, bool) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case bool:
+		s = strconv.FormatBool(v)
+	default:
+		return nil, false
+	}
+
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		if t != jsonNumberType {
+			return s, true
+		}
+		_, err = strconv.ParseFloat(s, 64)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		return b, err == nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, err = strconv.ParseInt(s, 10, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		_, err = strconv.ParseUint(s, 10, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(s, t.Bits())
+	default:
+		return nil, false
+	}
+	return json.Number(s), err == nil
+}
+
+type jsonField struct {
+	typ    reflect.Type
+	quoted bool
+}
+
+// jsonFields returns the fields of struct type t by JSON name, including
+// those of embedded structs, as encoding/json sees them.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := map[string]jsonField{}
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			embedded = append(embedded, ft)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		quoted := false
+		for _, o := range strings.Split(opts, ",") {
+			quoted = quoted || o == "string"
+		}
+		fields[name] = jsonField{typ: f.Type, quoted: quoted}
+	}
+	// Fields of the outer struct take precedence over embedded ones.
+	for _, et := range embedded {
+		for name, f := range jsonFields(et) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = f
+			}
+		}
+	}
+	return fields
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, k string) string {
+	if path == "" {
+		return k
+	}
+	return path + "." + k
+}
+
+func rawJSON(v interface{}) string {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(bs)
+}
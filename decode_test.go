@@ -0,0 +1,93 @@
+package robinhood
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	c := &Client{}
+	var md MarketData
+	require.Error(t, c.decode("", []byte(`{"delta": "", "gamma": "1.5"}`), &md))
+	md = MarketData{}
+	require.NoError(t, c.decode("", []byte(`{"delta": null, "gamma": "1.5"}`), &md))
+	require.Nil(t, md.Delta)
+	require.Equal(t, 1.5, *md.Gamma)
+
+	var o OrderOutput
+	require.NoError(t, c.decode("", []byte(`{"price": "1.5", "stop_price": null}`), &o))
+	require.Equal(t, "1.5", o.Price.String())
+}
+
+func TestDecodeLenient(t *testing.T) {
+	var warnings []DecodeWarning
+	c := &Client{
+		Decode:          DecodeLenient,
+		OnDecodeWarning: func(w DecodeWarning) { warnings = append(warnings, w) },
+	}
+
+	var q struct{ Results []Quote }
+	require.NoError(t, c.decode("u", []byte(`{"results": [{"symbol": "SPY", "ask_price": "", "bid_price": "1.5", "ask_size": "3", "new_field": 1}]}`), &q))
+	require.Equal(t, []Quote{{Symbol: "SPY", BidPrice: NewDecimal(15, 1), AskSize: 3}}, q.Results)
+	require.Equal(t, []DecodeWarning{
+		{URL: "u", Path: "results[0].ask_size", Type: "int", Value: `"3"`},
+		{URL: "u", Path: "results[0].new_field", Value: "1"},
+	}, warnings)
+	require.True(t, warnings[1].Unknown())
+
+	warnings = nil
+	var md MarketData
+	require.NoError(t, c.decode("u", []byte(`{"delta": null, "gamma": "", "rho": 0.012, "theta": "-0.05", "mark_price": "abc", "previous_close_date": null, "volume": 12}`), &md))
+	require.Equal(t, MarketData{Rho: "0.012", Theta: "-0.05", Volume: 12}, md)
+	require.Equal(t, []DecodeWarning{
+		{URL: "u", Path: "mark_price", Type: "robinhood.Decimal", Value: `"abc"`},
+		{URL: "u", Path: "rho", Type: "string", Value: "0.012"},
+	}, warnings)
+
+	warnings = nil
+	var o OrderOutput
+	require.NoError(t, c.decode("u", []byte(`{"average_price": "", "stop_price": null, "price": 2.5, "created_at": "", "updated_at": "2020-01-02T03:04:05Z"}`), &o))
+	require.Equal(t, "2.5", o.Price.String())
+	require.Equal(t, 2020, o.UpdatedAt.Year())
+	require.Empty(t, warnings)
+
+	warnings = nil
+	var a Account
+	require.NoError(t, c.decode("u", []byte(`{"account_number": "1", "cash": "", "margin_balances": {"cash": "10.5", "margin_limit": null}, "cash_balances": ""}`), &a))
+	require.Equal(t, "1", a.AccountNumber)
+	require.Equal(t, "10.5", a.MarginBalances.Cash.String())
+	require.Empty(t, warnings)
+
+	var p Position
+	require.NoError(t, c.decode("u", []byte(`{"quantity": 3, "average_buy_price": "12.25", "shares_held_for_buys": ""}`), &p))
+	require.Equal(t, Position{Quantity: NewDecimal(3, 0), AverageBuyPrice: MustParseDecimal("12.25")}, p)
+	require.Empty(t, warnings)
+}
+
+func TestDecodeInvalidDest(t *testing.T) {
+	body := []byte(`{"price": "1.5"}`)
+	strict := (&Client{}).decode("u", body, nil)
+	require.EqualError(t, strict, "error decoding the response: json: Unmarshal(nil)")
+	for _, c := range []*Client{
+		{Decode: DecodeLenient},
+		{OnDecodeWarning: func(DecodeWarning) {}},
+	} {
+		require.Equal(t, strict.Error(), c.decode("u", body, nil).Error())
+		var o OrderOutput
+		require.EqualError(t, c.decode("u", body, o), "error decoding the response: json: Unmarshal(non-pointer robinhood.OrderOutput)")
+		require.EqualError(t, c.decode("u", body, (*OrderOutput)(nil)), "error decoding the response: json: Unmarshal(nil *robinhood.OrderOutput)")
+	}
+}
+
+func TestDecodeWarningsStrict(t *testing.T) {
+	var warnings []DecodeWarning
+	c := &Client{OnDecodeWarning: func(w DecodeWarning) { warnings = append(warnings, w) }}
+
+	var md MarketData
+	require.Error(t, c.decode("u", []byte(`{"delta": 3, "extra": true}`), &md))
+	require.Equal(t, []DecodeWarning{
+		{URL: "u", Path: "delta", Type: "float64", Value: "3"},
+		{URL: "u", Path: "extra", Value: "true"},
+	}, warnings)
+}
@@ -0,0 +1,191 @@
+package robinhood
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// A DialOption configures the Client returned by Dial.
+type DialOption func(*dialConfig)
+
+type dialConfig struct {
+	c *Client
+
+	httpClient    *http.Client
+	accountNumber string
+	accountType   string
+	skipCrypto    bool
+}
+
+// WithBaseURLs routes all requests made by the client to the given API and
+// crypto (nummus) base URLs instead of the Robinhood production hosts. Either
+// may be left empty to keep the default.
+func WithBaseURLs(api, crypto string) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.c.BaseURL = api
+		cfg.c.CryptoBaseURL = crypto
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy as the client's retry policy.
+// A nil policy disables retries.
+func WithRetryPolicy(p RetryPolicy) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.c.Retry = p
+	}
+}
+
+// WithOrderRetries sets Client.RetryOrders, so that order placements are
+// retried like other idempotent requests. See RetryOrders for the
+// assumption this makes about the API.
+func WithOrderRetries() DialOption {
+	return func(cfg *dialConfig) {
+		cfg.c.RetryOrders = true
+	}
+}
+
+// WithRateLimit limits the client to rate requests per second, in bursts of
+// up to burst requests, to each of the API and crypto hosts.
+func WithRateLimit(rate float64, burst int) DialOption {
+	return WithRateLimiter(NewHostRateLimiter(rate, burst))
+}
+
+// WithRateLimiter sets the client's RateLimiter. A limiter may be shared by
+// several clients to limit them together.
+func WithRateLimiter(l RateLimiter) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.c.Limiter = l
+	}
+}
+
+// WithHTTPClient makes the client send requests through a copy of hc whose
+// transport is wrapped to add the oauth2 token. The timeout, cookie jar and
+// redirect policy of hc are kept.
+func WithHTTPClient(hc *http.Client) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.httpClient = hc
+	}
+}
+
+// WithTransport makes the client send requests through rt.
+func WithTransport(rt http.RoundTripper) DialOption {
+	return WithHTTPClient(&http.Client{Transport: rt})
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.c.UserAgent = ua
+	}
+}
+
+// WithHook sets the client's Hook, e.g. NewLogHook(slog.Default()).
+func WithHook(h Hook) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.c.Hook = h
+	}
+}
+
+// WithDecodeMode sets the client's DecodeMode, e.g. DecodeLenient.
+func WithDecodeMode(m DecodeMode) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.c.Decode = m
+	}
+}
+
+// WithDecodeWarnings makes the client call fn for every unknown or
+// mismatched field found while decoding responses.
+func WithDecodeWarnings(fn func(DecodeWarning)) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.c.OnDecodeWarning = fn
+	}
+}
+
+// WithTickPolicy sets the client's TickPolicy, e.g. TickRound.
+func WithTickPolicy(p TickPolicy) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.c.Ticks = p
+	}
+}
+
+// WithAccountNumber selects the brokerage account with the given number as
+// Client.Account, instead of the first one returned by the API. Dial fails if
+// there is no such account.
+func WithAccountNumber(number string) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.accountNumber = number
+	}
+}
+
+// WithAccountType selects the first brokerage account of the given type, as
+// for Client.SelectAccountType, as Client.Account. Dial fails if there is no
+// such account.
+func WithAccountType(typ string) DialOption {
+	return func(cfg *dialConfig) {
+		cfg.accountType = typ
+	}
+}
+
+// WithoutCrypto skips looking up the crypto account, leaving
+// Client.CryptoAccount nil. Use it for logins without crypto trading, for
+// which the crypto API may fail.
+func WithoutCrypto() DialOption {
+	return func(cfg *dialConfig) {
+		cfg.skipCrypto = true
+	}
+}
+
+// Dial returns a client given a TokenGetter. TokenGetter implementations are
+// available in this package, including a Cookie-based cache.
+func Dial(ctx context.Context, s oauth2.TokenSource, opts ...DialOption) (*Client, error) {
+	cfg := &dialConfig{
+		c: &Client{Retry: DefaultRetryPolicy},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	c := cfg.c
+
+	if cfg.httpClient != nil {
+		hc := *cfg.httpClient
+		hc.Transport = &oauth2.Transport{
+			Base:   cfg.httpClient.Transport,
+			Source: oauth2.ReuseTokenSource(nil, s),
+		}
+		c.Client = &hc
+	} else {
+		c.Client = oauth2.NewClient(ctx, s)
+	}
+
+	var err error
+	switch {
+	case cfg.accountNumber != "":
+		err = c.SelectAccount(ctx, cfg.accountNumber)
+	case cfg.accountType != "":
+		err = c.SelectAccountType(ctx, cfg.accountType)
+	default:
+		var a []Account
+		if a, err = c.GetAccounts(ctx); len(a) > 0 {
+			c.Account = &a[0]
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting accounts")
+	}
+
+	if cfg.skipCrypto {
+		return c, nil
+	}
+	ca, err := c.GetCryptoAccounts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting crypto accounts")
+	}
+
+	if len(ca) > 0 {
+		c.CryptoAccount = &ca[0]
+	}
+	return c, nil
+}
@@ -0,0 +1,81 @@
+package robinhood
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+type countingTransport struct {
+	n int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.n++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestDialOptions(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	ira := srv.AddAccount(robinhoodtest.Account{AccountNumber: "5RY00002", Type: "cash"})
+	srv.AddFault(robinhoodtest.Fault{Path: "/nummus/", Status: http.StatusForbidden})
+
+	_, err := dialTestErr(srv)
+	require.True(t, IsUnauthorized(err))
+
+	rt := &countingTransport{}
+	c, err := dialTestErr(srv,
+		WithAccountNumber(ira.AccountNumber),
+		WithoutCrypto(),
+		WithTransport(rt),
+		WithUserAgent("robinhood-test/1.0"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, ira.AccountNumber, c.Account.AccountNumber)
+	require.Nil(t, c.CryptoAccount)
+	require.Equal(t, 1, rt.n)
+	_, err = c.CryptoOrder(ctx, CryptoCurrencyPair{ID: "btc"}, CryptoOrderOpts{Side: Buy, Type: Market, Quantity: NewDecimal(1, 0)})
+	require.EqualError(t, err, "no crypto account")
+
+	reqs := srv.Requests()
+	last := reqs[len(reqs)-1]
+	require.Equal(t, "robinhood-test/1.0", last.Header.Get("User-Agent"))
+	require.Equal(t, "Bearer "+robinhoodtest.DefaultToken, last.Header.Get("Authorization"))
+
+	_, err = dialTestErr(srv, WithAccountNumber("nope"), WithoutCrypto())
+	require.EqualError(t, err, `error getting accounts: no account with number "nope"`)
+	_, err = c.GetAccounts(ctx)
+	require.NoError(t, err)
+}
+
+func TestDialHTTPClient(t *testing.T) {
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	rt := &countingTransport{}
+	hc := &http.Client{
+		Transport:     rt,
+		Jar:           jar,
+		Timeout:       time.Minute,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	c, err := dialTestErr(srv, WithHTTPClient(hc), WithoutCrypto())
+	require.NoError(t, err)
+	require.Equal(t, 1, rt.n)
+	require.Equal(t, jar, c.Client.Jar)
+	require.Equal(t, time.Minute, c.Client.Timeout)
+	require.NotNil(t, c.Client.CheckRedirect)
+	require.Equal(t, rt, hc.Transport, "the caller's client is not modified")
+
+	reqs := srv.Requests()
+	require.Equal(t, "Bearer "+robinhoodtest.DefaultToken, reqs[len(reqs)-1].Header.Get("Authorization"))
+}
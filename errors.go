@@ -0,0 +1,131 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned by DoAndDecode for any response with a status code of
+// 400 or above. Use errors.As, or one of the Is* helpers, to inspect it.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	URL        string
+
+	// Detail is the "detail" message of the response, if any.
+	Detail string
+	// Fields holds any other error messages in the response, keyed by the
+	// request field they refer to (or "non_field_errors").
+	Fields map[string][]string
+	// Body is the raw response body.
+	Body []byte
+}
+
+func newAPIError(req *http.Request, res *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Body:       body,
+	}
+
+	var m map[string]interface{}
+	if json.Unmarshal(body, &m) != nil {
+		return e
+	}
+	for k, v := range m {
+		var msgs []string
+		switch v := v.(type) {
+		case string:
+			msgs = []string{v}
+		case []interface{}:
+			for _, msg := range v {
+				msgs = append(msgs, fmt.Sprint(msg))
+			}
+		case nil:
+			continue
+		default:
+			msgs = []string{fmt.Sprint(v)}
+		}
+		if k == "detail" {
+			e.Detail = strings.Join(msgs, " ")
+			continue
+		}
+		if e.Fields == nil {
+			e.Fields = map[string][]string{}
+		}
+		e.Fields[k] = msgs
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	msgs := []string{}
+	if e.Detail != "" {
+		msgs = append(msgs, e.Detail)
+	}
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", k, strings.Join(e.Fields[k], " ")))
+	}
+	if len(msgs) == 0 && len(e.Body) > 0 {
+		msgs = append(msgs, fmt.Sprintf("%q", e.Body))
+	}
+
+	s := fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Status)
+	if len(msgs) > 0 {
+		s += ": " + strings.Join(msgs, "; ")
+	}
+	return s
+}
+
+// Retryable reports whether the request may succeed if repeated unchanged,
+// i.e. the server was throttling or failed internally.
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+func hasStatus(err error, codes ...int) bool {
+	var e *APIError
+	if !errors.As(err, &e) {
+		return false
+	}
+	for _, c := range codes {
+		if e.StatusCode == c {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is an APIError for a 404 response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether err is an APIError for a 429 response.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsUnauthorized reports whether err is an APIError for a 401 or 403
+// response, usually meaning the token has expired or been revoked.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// IsRetryable reports whether err is an APIError that is Retryable.
+func IsRetryable(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.Retryable()
+}
@@ -0,0 +1,55 @@
+package robinhood
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestAPIError(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+
+	c, err := Dial(ctx, srv.TokenSource(), WithBaseURLs(srv.URL(), srv.CryptoURL()))
+	require.NoError(t, err)
+
+	_, err = c.GetInstrument(ctx, srv.URL()+"instruments/missing/")
+	require.True(t, IsNotFound(err))
+	require.False(t, IsRetryable(err))
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, "GET", apiErr.Method)
+	require.Equal(t, "Not found.", apiErr.Detail)
+
+	bad := &Client{
+		Client:  oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "expired"})),
+		BaseURL: srv.URL(),
+	}
+	_, err = bad.GetAccounts(ctx)
+	require.True(t, IsUnauthorized(err))
+
+	_, err = (&OAuth{Username: "nobody", BaseURL: srv.URL()}).Token()
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	req, err := http.NewRequest("POST", EPOrders, nil)
+	require.NoError(t, err)
+	res := &http.Response{StatusCode: 400, Status: "400 Bad Request"}
+
+	e := newAPIError(req, res, []byte(`{"quantity": ["Order quantity has invalid increment."], "price": ["Invalid price."], "detail": null}`))
+	require.Equal(t, []string{"Invalid price."}, e.Fields["price"])
+	require.Equal(t, `POST `+EPOrders+`: 400 Bad Request: price: Invalid price.; quantity: Order quantity has invalid increment.`, e.Error())
+
+	res = &http.Response{StatusCode: 502, Status: "502 Bad Gateway"}
+	e = newAPIError(req, res, []byte("<html>bad gateway</html>"))
+	require.True(t, e.Retryable())
+	require.Contains(t, e.Error(), `"<html>bad gateway</html>"`)
+}
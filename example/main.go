@@ -102,7 +102,7 @@ func doit() {
 			log.Fatal("Error getting option order from iterator ", err)
 		}
 		for _, option := range val {
-			if option.State == robinhood.ORDER_STATE_CANCELLED {
+			if option.State == robinhood.Cancelled {
 				//continue
 			}
 			printJSON(option)
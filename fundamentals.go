@@ -6,14 +6,14 @@ import (
 )
 
 type Fundamental struct {
-	Open          float64 `json:"open,string"`
-	High          float64 `json:"high,string"`
-	Low           float64 `json:"low,string"`
+	Open          Decimal `json:"open"`
+	High          Decimal `json:"high"`
+	Low           Decimal `json:"low"`
 	Volume        float64 `json:"volume,string"`
 	AverageVolume float64 `json:"average_volume,string"`
-	High52Weeks   float64 `json:"high_52_weeks,string"`
+	High52Weeks   Decimal `json:"high_52_weeks"`
 	DividendYield float64 `json:"dividend_yield,string"`
-	Low52Weeks    float64 `json:"low_52_weeks,string"`
+	Low52Weeks    Decimal `json:"low_52_weeks"`
 	MarketCap     float64 `json:"market_cap,string"`
 	PERatio       float64 `json:"pe_ratio,string"`
 	Description   string  `json:"description"`
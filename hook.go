@@ -0,0 +1,162 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nikunjy/robinhood/internal/redact"
+)
+
+// RequestInfo describes a request sent by a Client. Credentials in the
+// header and body have already been redacted.
+type RequestInfo struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// ResponseInfo describes the outcome of a request. Err is set, and
+// StatusCode is zero, if no response was received.
+type ResponseInfo struct {
+	Request    RequestInfo
+	StatusCode int
+	Latency    time.Duration
+	Body       []byte
+	Err        error
+}
+
+// A Hook observes every request a Client sends, including retries.
+type Hook interface {
+	BeforeRequest(ctx context.Context, req *RequestInfo)
+	AfterResponse(ctx context.Context, res *ResponseInfo)
+}
+
+// Logger is the structured logging interface used by LogHook. It is
+// satisfied by *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// DefaultMaxLogBody is the number of body bytes logged by a LogHook unless
+// MaxBody is set.
+const DefaultMaxLogBody = 512
+
+// LogHook is a Hook logging requests at debug level and responses at debug,
+// warn (status 400 or above) or error (no response) level.
+type LogHook struct {
+	// Logger receives the log records. If nil, records are written with
+	// the standard log package.
+	Logger Logger
+	// MaxBody truncates logged bodies. Zero means DefaultMaxLogBody, and a
+	// negative value omits bodies.
+	MaxBody int
+}
+
+// NewLogHook returns a LogHook writing to l.
+func NewLogHook(l Logger) *LogHook {
+	return &LogHook{Logger: l}
+}
+
+// BeforeRequest implements Hook.
+func (h *LogHook) BeforeRequest(ctx context.Context, req *RequestInfo) {
+	h.logger().Debug("robinhood request",
+		"method", req.Method,
+		"url", req.URL,
+		"body", h.snippet(req.Body),
+	)
+}
+
+// AfterResponse implements Hook.
+func (h *LogHook) AfterResponse(ctx context.Context, res *ResponseInfo) {
+	l := h.logger()
+	args := []interface{}{
+		"method", res.Request.Method,
+		"url", res.Request.URL,
+		"status", res.StatusCode,
+		"latency", res.Latency,
+	}
+	switch {
+	case res.Err != nil:
+		l.Error("robinhood response", append(args, "error", res.Err)...)
+	case res.StatusCode >= 400:
+		l.Warn("robinhood response", append(args, "body", h.snippet(res.Body))...)
+	default:
+		l.Debug("robinhood response", append(args, "body", h.snippet(res.Body))...)
+	}
+}
+
+func (h *LogHook) logger() Logger {
+	if h.Logger == nil {
+		return stdLogger{}
+	}
+	return h.Logger
+}
+
+func (h *LogHook) snippet(b []byte) string {
+	max := h.MaxBody
+	if max == 0 {
+		max = DefaultMaxLogBody
+	}
+	if max < 0 {
+		return ""
+	}
+	if len(b) > max {
+		return string(b[:max]) + "..."
+	}
+	return string(b)
+}
+
+// stdLogger writes key/value records with the standard log package.
+type stdLogger struct{}
+
+func (stdLogger) Debug(msg string, args ...interface{}) { stdLog("DEBUG", msg, args) }
+func (stdLogger) Info(msg string, args ...interface{})  { stdLog("INFO", msg, args) }
+func (stdLogger) Warn(msg string, args ...interface{})  { stdLog("WARN", msg, args) }
+func (stdLogger) Error(msg string, args ...interface{}) { stdLog("ERROR", msg, args) }
+
+func stdLog(level, msg string, args []interface{}) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", level, msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%q", args[i], fmt.Sprint(args[i+1]))
+	}
+	log.Print(b.String())
+}
+
+// RedactHeader returns a copy of h with credentials replaced.
+func RedactHeader(h http.Header) http.Header {
+	return redact.Header(h)
+}
+
+// RedactBody returns a copy of a JSON or form-encoded body with passwords,
+// MFA codes and tokens replaced. Other bodies are returned unchanged.
+func RedactBody(b []byte) []byte {
+	return redact.Body(b)
+}
+
+// requestInfo captures a redacted copy of req, reading its body through
+// GetBody so the request itself is left intact.
+func requestInfo(req *http.Request) RequestInfo {
+	info := RequestInfo{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: RedactHeader(req.Header),
+	}
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			b, _ := ioutil.ReadAll(rc)
+			rc.Close()
+			info.Body = RedactBody(b)
+		}
+	}
+	return info
+}
@@ -0,0 +1,61 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) log(level, msg string, args []interface{}) {
+	l.lines = append(l.lines, fmt.Sprint(level, " ", msg, " ", args))
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) { l.log("DEBUG", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...interface{})  { l.log("INFO", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...interface{})  { l.log("WARN", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...interface{}) { l.log("ERROR", msg, args) }
+
+func TestLogHook(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.Username, srv.Password = "user", "hunter2"
+
+	l := &recordingLogger{}
+	o := &OAuth{Username: "user", Password: "hunter2", BaseURL: srv.URL(), Hook: NewLogHook(l)}
+	tok, err := o.Token()
+	require.NoError(t, err)
+
+	c := dialTest(t, srv, WithHook(&LogHook{Logger: l, MaxBody: 20}), WithoutCrypto())
+	_, err = c.GetInstrument(ctx, srv.URL()+"instruments/missing/")
+	require.True(t, IsNotFound(err))
+
+	all := strings.Join(l.lines, "\n")
+	require.NotContains(t, all, "hunter2")
+	require.NotContains(t, all, tok.AccessToken)
+	require.Contains(t, all, "password=REDACTED")
+	require.Contains(t, all, `"access_token":"REDACTED"`)
+	require.Contains(t, l.lines[len(l.lines)-1], "WARN robinhood response")
+	require.Contains(t, l.lines[len(l.lines)-1], "status 404")
+}
+
+func TestRedact(t *testing.T) {
+	h := RedactHeader(http.Header{"Authorization": {"Bearer abc"}, "Accept": {"application/json"}})
+	require.Equal(t, "REDACTED", h.Get("Authorization"))
+	require.Equal(t, "application/json", h.Get("Accept"))
+
+	require.JSONEq(t,
+		`{"user": {"mfa_code": "REDACTED", "name": "x"}, "refresh_token": "REDACTED", "price": 1.10}`,
+		string(RedactBody([]byte(`{"user": {"mfa_code": "123456", "name": "x"}, "refresh_token": "r", "price": 1.10}`))))
+	require.Equal(t, `{"price": 1.10}`, string(RedactBody([]byte(`{"price": 1.10}`))))
+	require.Equal(t, "mfa_code=REDACTED&username=u", string(RedactBody([]byte("username=u&mfa_code=1"))))
+}
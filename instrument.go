@@ -19,7 +19,7 @@ type Instrument struct {
 	MaintenanceRatio      string      `json:"maintenance_ratio"`
 	MarginInitialRatio    string      `json:"margin_initial_ratio"`
 	Market                string      `json:"market"`
-	MinTickSize           interface{} `json:"min_tick_size"`
+	MinTickSize           Decimal     `json:"min_tick_size"`
 	Name                  string      `json:"name"`
 	Quote                 string      `json:"quote"`
 	RhsTradability        string      `json:"rhs_tradability"`
@@ -34,6 +34,12 @@ type Instrument struct {
 	URL                   string      `json:"url"`
 }
 
+// IsFractionallyTradable reports whether fractions of a share of the
+// instrument can be traded.
+func (i Instrument) IsFractionallyTradable() bool {
+	return i.FractionalTradability == "tradable"
+}
+
 func (i Instrument) OrderURL() string {
 	return i.URL
 }
@@ -0,0 +1,99 @@
+// Package redact removes credentials from HTTP headers and bodies before
+// they are logged or recorded.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Value replaces every redacted header or field value.
+const Value = "REDACTED"
+
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"mfa_code":      true,
+	"access_token":  true,
+	"refresh_token": true,
+	"token":         true,
+	"device_token":  true,
+	"client_secret": true,
+}
+
+// Header returns a copy of h with credentials replaced.
+func Header(h http.Header) http.Header {
+	out := h.Clone()
+	if out == nil {
+		out = http.Header{}
+	}
+	for _, k := range sensitiveHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, Value)
+		}
+	}
+	return out
+}
+
+// Body returns a copy of a JSON or form-encoded body with passwords, MFA
+// codes and tokens replaced. Other bodies, and bodies without credentials,
+// are returned unchanged.
+func Body(b []byte) []byte {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return b
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err == nil {
+		if !redactJSON(v) {
+			return b
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			return b
+		}
+		return out
+	}
+
+	if q, err := url.ParseQuery(string(b)); err == nil {
+		changed := false
+		for k := range q {
+			if sensitiveFields[k] {
+				q.Set(k, Value)
+				changed = true
+			}
+		}
+		if changed {
+			return []byte(q.Encode())
+		}
+	}
+	return b
+}
+
+// redactJSON redacts v in place and reports whether anything was redacted.
+func redactJSON(v interface{}) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, x := range v {
+			if sensitiveFields[k] {
+				v[k] = Value
+				changed = true
+			} else if redactJSON(x) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, x := range v {
+			if redactJSON(x) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
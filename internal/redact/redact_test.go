@@ -0,0 +1,19 @@
+package redact
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	h := Header(http.Header{"Cookie": {"a=b"}, "Accept": {"application/json"}})
+	require.Equal(t, Value, h.Get("Cookie"))
+	require.Equal(t, "application/json", h.Get("Accept"))
+
+	require.Equal(t, `{"items":[{"token":"REDACTED"}],"name":"x"}`,
+		string(Body([]byte(`{"name": "x", "items": [{"token": "t"}]}`))))
+	require.Equal(t, "password=REDACTED&username=u", string(Body([]byte("username=u&password=p"))))
+	require.Equal(t, "not a form; %zz", string(Body([]byte("not a form; %zz"))))
+}
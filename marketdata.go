@@ -13,7 +13,7 @@ type EntryPrice struct {
 type PriceBookEntry struct {
 	Side     string
 	Price    EntryPrice
-	Quantity float64
+	Quantity Decimal
 }
 
 type PriceBookData struct {
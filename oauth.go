@@ -3,7 +3,6 @@ package robinhood
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -18,7 +17,24 @@ const DefaultClientID = "c82SH0WZOsabOXGP2sxqcj34FxkvfnWRZBKlBjFS"
 
 // OAuth implements oauth2 using the robinhood implementation
 type OAuth struct {
-	Endpoint, ClientID, Username, Password, MFA string
+	// Endpoint is ignored.
+	//
+	// Deprecated: use BaseURL to send logins to another host.
+	Endpoint string
+
+	ClientID, Username, Password, MFA string
+
+	// BaseURL, if set, replaces EPBase when building the login URL,
+	// matching Client.BaseURL.
+	BaseURL string
+
+	// HTTPClient is used to request tokens. If nil, http.DefaultClient is
+	// used.
+	HTTPClient *http.Client
+
+	// Hook, if set, observes the login requests. Passwords, MFA codes and
+	// tokens are redacted before it sees them.
+	Hook Hook
 }
 
 // ErrMFARequired indicates the MFA was required but not provided.
@@ -35,7 +51,10 @@ func (p *OAuth) Token() (*oauth2.Token, error) {
 		cliID = DefaultClientID
 	}
 
-	u, _ := url.Parse(EPLogin)
+	u, err := url.Parse(rebaseURL(EPLogin, p.BaseURL, ""))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse login URL")
+	}
 	q := u.Query()
 	q.Add("expires_in", fmt.Sprint(24*time.Hour/time.Second))
 	q.Add("client_id", cliID)
@@ -62,28 +81,32 @@ func (p *OAuth) Token() (*oauth2.Token, error) {
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
-	res, err := http.DefaultClient.Do(req)
+	hc := p.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, data, err := (&Client{Client: hc, Hook: p.Hook}).roundTrip(req)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not post login")
 	}
-	defer res.Body.Close()
 	var o struct {
 		oauth2.Token
 		ExpiresIn   int    `json:"expires_in"`
 		MFARequired bool   `json:"mfa_required"`
 		MFAType     string `json:"mfa_type"`
 	}
-	data, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
+	// The login endpoint asks for an MFA code with an error status, so
+	// check for that before treating the response as an error.
 	err = json.Unmarshal(data, &o)
+	if err == nil && o.MFARequired {
+		return nil, ErrMFARequired
+	}
+	if res.StatusCode >= 400 {
+		return nil, newAPIError(req, res, data)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "could not decode token")
 	}
-	if o.MFARequired {
-		return nil, ErrMFARequired
-	}
 	o.Token.Expiry = time.Now().Add(time.Duration(o.ExpiresIn) * time.Second)
 	return &o.Token, nil
 }
@@ -0,0 +1,294 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// An OptionLeg is one leg of a multi-leg options order.
+type OptionLeg struct {
+	Option *OptionInstrument
+
+	// Side is the side of the leg. It may be left zero if PositionEffect
+	// is set.
+	Side OrderSide
+
+	// Ratio is the number of contracts of this leg per unit of the order's
+	// quantity. The default is 1.
+	Ratio Decimal
+
+	// PositionEffect is whether the leg opens or closes a position. The
+	// default opens one on Side.
+	PositionEffect PositionEffect
+}
+
+// OrderOptionLegs places an order for a combination of option legs, e.g.
+// one built by VerticalSpread or IronCondor. Quantity, Price, Type,
+// TimeInForce, RefID and Account are taken from o, and Price is the net
+// price of one unit of the combination.
+//
+// The Side, PositionEffect and Direction of o are ignored: the order is a
+// Debit if the legs bought are worth more, at their current mark prices,
+// than the legs sold, and a Credit otherwise. Legs that close a position are
+// checked against the options positions held in the account.
+func (c *Client) OrderOptionLegs(ctx context.Context, legs []OptionLeg, o OptionsOrderOpts) (*OptionOrder, error) {
+	acct, err := c.account(o.Account)
+	if err != nil {
+		return nil, err
+	}
+	legs, err = checkLegs(legs)
+	if err != nil {
+		return nil, err
+	}
+	price, err := c.checkPrice(o.Price, legsTick(legs, o.Price), o.Type == Limit)
+	if err != nil {
+		return nil, err
+	}
+	qty, err := c.checkQuantity(o.Quantity, wholeContract)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkCloses(ctx, acct, qty, legs); err != nil {
+		return nil, err
+	}
+	dir, err := c.legsDirection(ctx, legs)
+	if err != nil {
+		return nil, err
+	}
+
+	b := optionInput{
+		Account:     acct.URL,
+		Direction:   dir,
+		TimeInForce: o.TimeInForce,
+		Trigger:     "immediate",
+		Type:        o.Type,
+		Quantity:    qty,
+		Price:       price,
+		RefID:       refID(o.RefID),
+	}
+	for _, l := range legs {
+		b.Legs = append(b.Legs, Leg{
+			Option:         l.Option.URL,
+			PositionEffect: l.PositionEffect.effect(),
+			RatioQuantity:  l.Ratio,
+			Side:           l.Side,
+		})
+	}
+
+	return c.postOptionsOrder(ctx, b)
+}
+
+// checkLegs validates legs and returns a copy with defaults filled in.
+func checkLegs(legs []OptionLeg) ([]OptionLeg, error) {
+	if len(legs) == 0 {
+		return nil, &ValidationError{Field: "legs", Reason: "at least one leg is required"}
+	}
+	out := make([]OptionLeg, len(legs))
+	seen := map[string]bool{}
+	for i, l := range legs {
+		switch {
+		case l.Option == nil:
+			return nil, &ValidationError{Field: "legs", Reason: fmt.Sprintf("leg %d has no option", i)}
+		case seen[l.Option.URL]:
+			return nil, &ValidationError{Field: "legs", Value: l.Option.URL, Reason: "option is in more than one leg"}
+		case l.PositionEffect == 0 && l.Side != Buy && l.Side != Sell:
+			return nil, &ValidationError{Field: "legs", Reason: fmt.Sprintf("leg %d has no side", i)}
+		}
+		seen[l.Option.URL] = true
+		if l.Ratio.IsZero() {
+			l.Ratio = NewDecimal(1, 0)
+		}
+		if l.Ratio.Sign() < 0 || !l.Ratio.IsMultipleOf(wholeContract) {
+			return nil, &ValidationError{Field: "ratio_quantity", Value: l.Ratio.String(), Reason: "must be a positive whole number"}
+		}
+		if l.PositionEffect == 0 {
+			l.PositionEffect = openOn(l.Side)
+		}
+		side, err := checkEffect(l.PositionEffect, l.Side)
+		if err != nil {
+			return nil, err
+		}
+		l.Side = side
+		out[i] = l
+	}
+	return out, nil
+}
+
+// legsTick returns the finest price increment of the legs at price.
+func legsTick(legs []OptionLeg, price Decimal) Decimal {
+	var tick Decimal
+	for _, l := range legs {
+		t := l.Option.MinTicks.Tick(price.Abs())
+		if !t.IsZero() && (tick.IsZero() || t.Cmp(tick) < 0) {
+			tick = t
+		}
+	}
+	return tick
+}
+
+// legsDirection works out whether an order for legs is a net debit or a net
+// credit from the mark prices of the options.
+func (c *Client) legsDirection(ctx context.Context, legs []OptionLeg) (OptionDirection, error) {
+	opts := make([]*OptionInstrument, len(legs))
+	for i, l := range legs {
+		opts[i] = l.Option
+	}
+	mds, err := c.MarketData(ctx, opts...)
+	if err != nil {
+		return Debit, errors.Wrap(err, "error getting option marks")
+	}
+	marks := map[string]Decimal{}
+	for _, md := range mds {
+		marks[md.Instrument] = md.MarkPrice
+	}
+
+	var net Decimal
+	for _, l := range legs {
+		mark, ok := marks[l.Option.URL]
+		if !ok {
+			return Debit, fmt.Errorf("no market data for option %s", l.Option.URL)
+		}
+		v := mark.Mul(l.Ratio)
+		if l.Side == Sell {
+			v = v.Neg()
+		}
+		net = net.Add(v)
+	}
+	if net.Sign() < 0 {
+		return Credit, nil
+	}
+	return Debit, nil
+}
+
+// ClosingLegs returns the legs that close a position opened with legs: the
+// same options and ratios on the opposite sides, with BuyToClose or
+// SellToClose position effects.
+func ClosingLegs(legs []OptionLeg) []OptionLeg {
+	out := make([]OptionLeg, len(legs))
+	for i, l := range legs {
+		side := l.Side
+		if l.PositionEffect != 0 {
+			side = l.PositionEffect.Side()
+		}
+		if side == Buy {
+			l.Side = Sell
+		} else {
+			l.Side = Buy
+		}
+		l.PositionEffect = closeOn(l.Side)
+		out[i] = l
+	}
+	return out
+}
+
+// VerticalSpread returns the legs of a vertical spread: long bought and
+// short sold, both of the same type and expiration with different strikes.
+func VerticalSpread(long, short *OptionInstrument) ([]OptionLeg, error) {
+	switch {
+	case long.Type != short.Type:
+		return nil, legsError("vertical spread", "options must be of the same type")
+	case !long.ExpirationDate.Equal(short.ExpirationDate.Time):
+		return nil, legsError("vertical spread", "options must have the same expiration date")
+	case long.StrikePrice.Equal(short.StrikePrice):
+		return nil, legsError("vertical spread", "options must have different strikes")
+	}
+	return []OptionLeg{
+		{Option: long, Side: Buy},
+		{Option: short, Side: Sell},
+	}, nil
+}
+
+// CalendarSpread returns the legs of a calendar spread: near sold and far
+// bought, both of the same type and strike with far expiring later.
+func CalendarSpread(near, far *OptionInstrument) ([]OptionLeg, error) {
+	switch {
+	case near.Type != far.Type:
+		return nil, legsError("calendar spread", "options must be of the same type")
+	case !near.StrikePrice.Equal(far.StrikePrice):
+		return nil, legsError("calendar spread", "options must have the same strike")
+	case !near.ExpirationDate.Before(far.ExpirationDate.Time):
+		return nil, legsError("calendar spread", "far option must expire after near option")
+	}
+	return []OptionLeg{
+		{Option: near, Side: Sell},
+		{Option: far, Side: Buy},
+	}, nil
+}
+
+// Straddle returns the legs of a straddle: a call and a put with the same
+// strike and expiration, both bought (a long straddle) or both sold (a
+// short straddle) according to side.
+func Straddle(call, put *OptionInstrument, side OrderSide) ([]OptionLeg, error) {
+	if err := checkCallPut("straddle", call, put); err != nil {
+		return nil, err
+	}
+	if !call.StrikePrice.Equal(put.StrikePrice) {
+		return nil, legsError("straddle", "options must have the same strike")
+	}
+	return []OptionLeg{
+		{Option: call, Side: side},
+		{Option: put, Side: side},
+	}, nil
+}
+
+// Strangle returns the legs of a strangle: an out-of-the-money call and put
+// with the same expiration, the call's strike above the put's, both bought
+// or both sold according to side.
+func Strangle(call, put *OptionInstrument, side OrderSide) ([]OptionLeg, error) {
+	if err := checkCallPut("strangle", call, put); err != nil {
+		return nil, err
+	}
+	if call.StrikePrice.Cmp(put.StrikePrice) <= 0 {
+		return nil, legsError("strangle", "call strike must be above put strike")
+	}
+	return []OptionLeg{
+		{Option: call, Side: side},
+		{Option: put, Side: side},
+	}, nil
+}
+
+// IronCondor returns the legs of a short iron condor: a bull put spread
+// (longPut bought, shortPut sold) below a bear call spread (shortCall sold,
+// longCall bought), all with the same expiration and strikes in the order
+// the arguments are given.
+func IronCondor(longPut, shortPut, shortCall, longCall *OptionInstrument) ([]OptionLeg, error) {
+	if err := checkCallPut("iron condor", shortCall, shortPut); err != nil {
+		return nil, err
+	}
+	if err := checkCallPut("iron condor", longCall, longPut); err != nil {
+		return nil, err
+	}
+	if !shortPut.ExpirationDate.Equal(longPut.ExpirationDate.Time) {
+		return nil, legsError("iron condor", "options must have the same expiration date")
+	}
+	strikes := []Decimal{longPut.StrikePrice, shortPut.StrikePrice, shortCall.StrikePrice, longCall.StrikePrice}
+	for i := 1; i < len(strikes); i++ {
+		if strikes[i].Cmp(strikes[i-1]) <= 0 {
+			return nil, legsError("iron condor", "strikes must increase from long put to long call")
+		}
+	}
+	return []OptionLeg{
+		{Option: longPut, Side: Buy},
+		{Option: shortPut, Side: Sell},
+		{Option: shortCall, Side: Sell},
+		{Option: longCall, Side: Buy},
+	}, nil
+}
+
+// checkCallPut checks that call and put are a call and a put with the same
+// expiration date.
+func checkCallPut(strategy string, call, put *OptionInstrument) error {
+	switch {
+	case call.Type != "call" || put.Type != "put":
+		return legsError(strategy, "needs a call and a put")
+	case !call.ExpirationDate.Equal(put.ExpirationDate.Time):
+		return legsError(strategy, "options must have the same expiration date")
+	}
+	return nil
+}
+
+func legsError(strategy, reason string) error {
+	return &ValidationError{Field: "legs", Reason: strategy + " " + reason}
+}
@@ -1,23 +1,36 @@
 package robinhood
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
 // OptionsOrderOpts encapsulates common Options order choices
 type OptionsOrderOpts struct {
-	Quantity    float64
-	Price       float64
+	Quantity    Decimal
+	Price       Decimal
 	Direction   OptionDirection
 	TimeInForce TimeInForce
 	Type        OrderType
 	Side        OrderSide
+
+	// PositionEffect is whether the order opens or closes a position, and
+	// on which side. If set, it also sets Side and Direction, and a Side
+	// that conflicts with it is an error. If zero, a Buy opens a position
+	// and a Sell closes one.
+	PositionEffect PositionEffect
+
+	// RefID identifies the order; see NewRefID. If empty, a new one is
+	// generated.
+	RefID string
+
+	// Account, if set, places the order in this account instead of the
+	// client's selected Account.
+	Account *Account
 }
 
 // optionInput is the input object to the RobinHood API
@@ -27,8 +40,8 @@ type optionInput struct {
 	Legs                   []Leg           `json:"legs"`
 	OverrideDayTradeChecks bool            `json:"override_day_trade_checks"`
 	OverrideDtbpChecks     bool            `json:"override_dtbp_checks"`
-	Price                  float64         `json:"price,string"`
-	Quantity               float64         `json:"quantity,string"`
+	Price                  Decimal         `json:"price"`
+	Quantity               Decimal         `json:"quantity"`
 	RefID                  string          `json:"ref_id"`
 	TimeInForce            TimeInForce     `json:"time_in_force"`
 	Trigger                string          `json:"trigger"`
@@ -40,146 +53,191 @@ type optionInput struct {
 type Leg struct {
 	Option         string    `json:"option"`
 	PositionEffect string    `json:"position_effect"`
-	RatioQuantity  float64   `json:"ratio_quantity,string"`
+	RatioQuantity  Decimal   `json:"ratio_quantity"`
 	Side           OrderSide `json:"side"`
 }
 
 // OrderOptions places a new order for options. Cancellation of the
 // context.Context will cancel the _http request_, never the order itself if it
 // has already been created.
-func (c *Client) OrderOptions(ctx context.Context, q *OptionInstrument, o OptionsOrderOpts) (json.RawMessage, error) {
-	b := optionInput{
-		Account:     c.Account.URL,
-		Direction:   o.Direction,
-		TimeInForce: o.TimeInForce,
-		Legs: []Leg{{
-			Option:         q.URL,
-			RatioQuantity:  1,
-			Side:           o.Side,
-			PositionEffect: "open",
-		}},
-		Trigger:  "immediate",
-		Type:     o.Type,
-		Quantity: o.Quantity,
-		Price:    o.Price,
-		RefID:    uuid.New().String(),
+//
+// An order whose PositionEffect closes a position is checked against the
+// options positions held in the account before it is placed. Without a
+// PositionEffect, the order is placed unchecked.
+func (c *Client) OrderOptions(ctx context.Context, q *OptionInstrument, o OptionsOrderOpts) (*OptionOrder, error) {
+	acct, err := c.account(o.Account)
+	if err != nil {
+		return nil, err
 	}
-
-	if o.Side != Buy {
-		b.Legs[0].PositionEffect = "close"
+	effect, dir := o.PositionEffect, o.Direction
+	if effect == 0 {
+		effect = BuyToOpen
+		if o.Side != Buy {
+			effect = SellToClose
+		}
+	} else {
+		if o.Side, err = checkEffect(effect, o.Side); err != nil {
+			return nil, err
+		}
+		dir = effect.direction()
 	}
-
-	bs, err := json.Marshal(b)
+	price, err := c.checkPrice(o.Price, q.MinTicks.Tick(o.Price), o.Type == Limit)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", EPOptions+"orders/", bytes.NewReader(bs))
+	qty, err := c.checkQuantity(o.Quantity, wholeContract)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	var out json.RawMessage
-	err = c.DoAndDecode(ctx, req, &out)
-	if err != nil {
-		return nil, err
+	leg := OptionLeg{Option: q, Side: o.Side, Ratio: NewDecimal(1, 0), PositionEffect: effect}
+	if o.PositionEffect != 0 {
+		if err := c.checkCloses(ctx, acct, qty, []OptionLeg{leg}); err != nil {
+			return nil, err
+		}
 	}
-	return out, nil
+
+	b := optionInput{
+		Account:     acct.URL,
+		Direction:   dir,
+		TimeInForce: o.TimeInForce,
+		Legs: []Leg{{
+			Option:         q.URL,
+			RatioQuantity:  leg.Ratio,
+			Side:           o.Side,
+			PositionEffect: effect.effect(),
+		}},
+		Trigger:  "immediate",
+		Type:     o.Type,
+		Quantity: qty,
+		Price:    price,
+		RefID:    refID(o.RefID),
+	}
+	return c.postOptionsOrder(ctx, b)
 }
 
-type OptionOrderState string
+// OptionOrderState is the state of an options order.
+//
+// Deprecated: use OrderState.
+type OptionOrderState = OrderState
 
 const (
-	ORDER_STATE_FILLED    OptionOrderState = "filled"
-	ORDER_STATE_CANCELLED OptionOrderState = "cancelled"
+	// Deprecated: use Filled.
+	ORDER_STATE_FILLED = Filled
+	// Deprecated: use Cancelled.
+	ORDER_STATE_CANCELLED = Cancelled
 )
 
 type OptionOrder struct {
 	CancelURL        string    `json:"cancel_url"`
-	CanceledQuantity float64   `json:"canceled_quantity,string"`
+	CanceledQuantity Decimal   `json:"canceled_quantity"`
 	CreatedAt        time.Time `json:"created_at,string"`
 	Direction        string    `json:"direction"`
 	ID               string    `json:"id"`
 	Legs             []struct {
-		Executions []struct {
-			ID             string
-			Price          float64   `json:"price,string"`
-			Quantity       float64   `json:"quantity,string"`
-			SettlementDate string    `json:"settlement_date"`
-			Timestamp      time.Time `json:"timestamp,string"`
-		} `json:"executions"`
-		ID             string  `json:"id"`
-		Instrument     string  `json:"option"`
-		PositionEffect string  `json:"position_effect"`
-		RatioQuantity  float64 `json:"ratio_quantity"`
-		Side           string  `json:"side"`
+		Executions     []Execution `json:"executions"`
+		ID             string      `json:"id"`
+		Instrument     string      `json:"option"`
+		PositionEffect string      `json:"position_effect"`
+		RatioQuantity  Decimal     `json:"ratio_quantity"`
+		Side           string      `json:"side"`
 	} `json:"legs"`
-	PendingQuantity   float64          `json:"pending_quantity,string"`
-	Premium           float64          `json:"premium,string"`
-	ProcessedPremium  float64          `json:"processed_premium,string"`
-	Price             float64          `json:"price,string"`
-	ProcessedQuantity float64          `json:"processed_quantity,string"`
-	Quantity          float64          `json:"quantity,string"`
-	RefID             string           `json:"ref_id"`
-	State             OptionOrderState `json:"state"`
-	Trigger           string           `json:"trigger"`
-	Type              string           `json:"type"`
-	UpdatedAt         time.Time        `json:"updated_at,string"`
-	ChainID           string           `json:"chain_id"`
-	ChainSymbol       string           `json:"chain_symbol"`
-	ClosingStrategy   string           `json:"closing_strategy"`
+	PendingQuantity   Decimal    `json:"pending_quantity"`
+	Premium           Decimal    `json:"premium"`
+	ProcessedPremium  Decimal    `json:"processed_premium"`
+	Price             Decimal    `json:"price"`
+	ProcessedQuantity Decimal    `json:"processed_quantity"`
+	Quantity          Decimal    `json:"quantity"`
+	RefID             string     `json:"ref_id"`
+	RejectReason      string     `json:"reject_reason"`
+	State             OrderState `json:"state"`
+	Trigger           string     `json:"trigger"`
+	Type              string     `json:"type"`
+	UpdatedAt         time.Time  `json:"updated_at,string"`
+	ChainID           string     `json:"chain_id"`
+	ChainSymbol       string     `json:"chain_symbol"`
+	ClosingStrategy   string     `json:"closing_strategy"`
+
+	client *Client
 }
 
 func (o OptionOrder) IsClosingOrder() bool {
 	return o.ClosingStrategy != ""
 }
 
+// postOptionsOrder places the options order b.
+func (c *Client) postOptionsOrder(ctx context.Context, b optionInput) (*OptionOrder, error) {
+	var out OptionOrder
+	if err := c.postOrder(ctx, EPOptions+"orders/", b.RefID, b, &out); err != nil {
+		return nil, err
+	}
+	out.client = c
+	return &out, nil
+}
+
+// Update returns any errors and updates the item with any recent changes.
+func (o *OptionOrder) Update(ctx context.Context) error {
+	return o.client.GetAndDecode(ctx, EPOptions+"orders/"+o.ID+"/", o)
+}
+
+// Cancel attempts to cancel the order.
+func (o *OptionOrder) Cancel(ctx context.Context) error {
+	if o.CancelURL == "" {
+		return fmt.Errorf("order %s cannot be cancelled", o.ID)
+	}
+	post, err := http.NewRequest("POST", o.CancelURL, nil)
+	if err != nil {
+		return err
+	}
+	var out OptionOrder
+	if err := o.client.DoAndDecode(ctx, post, &out); err != nil {
+		return errors.Wrap(err, "could not cancel order")
+	}
+	return nil
+}
+
 type OptionsOrdersIterator interface {
 	HasNext() bool
 	Next(ctx context.Context) ([]OptionOrder, error)
 }
 
 type optionsOrdersIterators struct {
-	c    *Client
-	next string
+	c     *Client
+	pages *PageIterator
 }
 
 func (o *optionsOrdersIterators) HasNext() bool {
-	return o.next != ""
+	return o.pages.HasNext()
 }
 
 func (o *optionsOrdersIterators) Next(ctx context.Context) ([]OptionOrder, error) {
-	var tmp struct {
-		Results []OptionOrder
-		Next    string
-	}
-	err := o.c.GetAndDecode(ctx, o.next, &tmp)
-	if err != nil {
+	var orders []OptionOrder
+	if err := o.pages.Next(ctx, &orders); err != nil {
 		return nil, err
 	}
-	o.next = tmp.Next
-	return tmp.Results, nil
+	for i := range orders {
+		orders[i].client = o.c
+	}
+	return orders, nil
 }
 
 // NewOptionsOrdersIterator returns an iterator which will return all
 // the option orders ever seen.
 func (c *Client) NewOptionsOrdersIterator() OptionsOrdersIterator {
+	return c.NewOptionsOrdersQueryIterator(OrderQuery{})
+}
+
+// NewOptionsOrdersQueryIterator returns an iterator which will return the
+// option orders matching q. Instrument does not apply to options orders.
+func (c *Client) NewOptionsOrdersQueryIterator(q OrderQuery) OptionsOrdersIterator {
 	return &optionsOrdersIterators{
-		next: EPOptions + "orders/",
-		c:    c,
+		c:     c,
+		pages: c.NewPageIterator(q.url(EPOptions + "orders/")),
 	}
 }
 
-// GetOptionsOrders returns all outstanding options orders
+// GetOptionsOrders returns every options order, open or not, most recent
+// first. Use QueryOptionsOrders to select orders by state.
 func (c *Client) GetOptionsOrders(ctx context.Context) ([]OptionOrder, error) {
-	var tmp struct {
-		Results []OptionOrder
-		Next    string
-	}
-	err := c.GetAndDecode(ctx, EPOptions+"orders/", &tmp)
-	if err != nil {
-		return nil, err
-	}
-	return tmp.Results, nil
+	return c.QueryOptionsOrders(ctx, OrderQuery{})
 }
@@ -55,18 +55,17 @@ func (c *Client) GetOptionChains(ctx context.Context, is ...*Instrument) ([]*Opt
 		s = append(s, inst.ID)
 	}
 
-	var res struct{ Results []*OptionChain }
-
-	err := c.GetAndDecode(ctx, EPOptions+"chains/?equity_instrument_ids="+strings.Join(s, ","), &res)
+	var chains []*OptionChain
+	err := c.getAll(ctx, EPOptions+"chains/?equity_instrument_ids="+strings.Join(s, ","), &chains)
 	if err != nil {
 		return nil, err
 	}
 
-	for i := range res.Results {
-		res.Results[i].c = c
+	for i := range chains {
+		chains[i].c = c
 	}
 
-	return res.Results, nil
+	return chains, nil
 }
 
 // OptionChain represents the data the RobinHood API holds behind options chains
@@ -77,12 +76,15 @@ type OptionChain struct {
 	ID                    string                 `json:"id"`
 	MinTicks              MinTicks               `json:"min_ticks"`
 	Symbol                string                 `json:"symbol"`
-	TradeValueMultiplier  float64                `json:"trade_value_multiplier,string"`
+	TradeValueMultiplier  Decimal                `json:"trade_value_multiplier"`
 	UnderlyingInstruments []UnderlyingInstrument `json:"underlying_instruments"`
 
 	c *Client
 }
 
+// Pager holds the links to the neighbouring pages of a list response.
+//
+// Deprecated: use PageIterator.
 type Pager struct {
 	Next, Previous string
 }
@@ -96,14 +98,16 @@ func (p *Pager) GetNext(ctx context.Context, c *Client, out interface{}) error {
 		return io.EOF
 	}
 
-	return c.GetAndDecode(ctx, p.Next, out)
+	// The last page has a null next link, which json leaves untouched, so
+	// clear the links before decoding the next page over them.
+	next := p.Next
+	p.Next, p.Previous = "", ""
+	return c.GetAndDecode(ctx, next, out)
 }
 
-// GetInstrument returns a list of option-typed instruments given a list of
-// expiration dates for a given trade type. The request will continue until the
-// provided context is cancelled. This is done to mimic the way the web UI
-// fetches many, many options instruments repeatedly, since I haven't yet
-// figured out how/when they decide to stop.
+// GetInstrument returns the active, tradable options of the chain of the
+// given trade type ("call" or "put") that expire on date. See ChainSnapshot
+// to fetch several expirations with their market data.
 func (o *OptionChain) GetInstrument(ctx context.Context, tradeType string, date Date) ([]*OptionInstrument, error) {
 	u := fmt.Sprintf(
 		"%sinstruments/?chain_id=%s&expiration_dates=%s&state=active&tradability=tradable&type=%s",
@@ -114,38 +118,15 @@ func (o *OptionChain) GetInstrument(ctx context.Context, tradeType string, date
 	)
 
 	var rs []*OptionInstrument
-	var out struct {
-		Results []*OptionInstrument
-		Pager
-	}
-	err := o.c.GetAndDecode(ctx, u, &out)
-	if err != nil {
-		return nil, err
-	}
-
-	rs = append(rs, out.Results...)
-
-	for out.HasMore() {
-		select {
-		case <-ctx.Done():
-			return rs, ctx.Err()
-		default:
-		}
-
-		err := out.GetNext(ctx, o.c, &out)
-		if err != nil {
-			return rs, err
-		}
-		rs = append(rs, out.Results...)
-	}
-	return rs, nil
+	err := o.c.getAll(ctx, u, &rs)
+	return rs, err
 }
 
 // MinTicks probably is important.
 type MinTicks struct {
-	AboveTick   float64 `json:"above_tick,string"`
-	BelowTick   float64 `json:"below_tick,string"`
-	CutoffPrice float64 `json:"cutoff_price,string"`
+	AboveTick   Decimal `json:"above_tick"`
+	BelowTick   Decimal `json:"below_tick"`
+	CutoffPrice Decimal `json:"cutoff_price"`
 }
 
 // UnderlyingInstrument is the type that represents a link from an option back
@@ -167,7 +148,7 @@ type OptionInstrument struct {
 	MinTicks       MinTicks `json:"min_ticks"`
 	RHSTradability string   `json:"rhs_tradability"`
 	State          string   `json:"state"`
-	StrikePrice    float64  `json:"strike_price,string"`
+	StrikePrice    Decimal  `json:"strike_price"`
 	Tradability    string   `json:"tradability"`
 	Type           string   `json:"type"`
 	UpdatedAt      string   `json:"updated_at"`
@@ -179,30 +160,30 @@ type OptionInstrument struct {
 // MarketData is the current pricing data and greeks for a given option at a
 // given time.
 type MarketData struct {
-	AdjustedMarkPrice   float64 `json:"adjusted_mark_price,string"`
-	AskPrice            float64 `json:"ask_price,string"`
-	AskSize             int     `json:"ask_size"`
-	BidPrice            float64 `json:"bid_price,string"`
-	BidSize             int     `json:"bid_size"`
-	BreakEvenPrice      float64 `json:"break_even_price,string"`
-	ChanceOfProfitLong  float64 `json:"chance_of_profit_long,string"`
-	ChanceOfProfitShort float64 `json:"chance_of_profit_short,string"`
-	Delta               float64 `json:"delta,string"`
-	Gamma               float64 `json:"gamma,string"`
-	HighPrice           float64 `json:"high_price,string"`
-	ImpliedVolatility   string  `json:"implied_volatility"`
-	Instrument          string  `json:"instrument"`
-	LastTradePrice      float64 `json:"last_trade_price,string"`
-	LastTradeSize       int     `json:"last_trade_size"`
-	LowPrice            float64 `json:"low_price,string"`
-	MarkPrice           float64 `json:"mark_price,string"`
-	OpenInterest        int     `json:"open_interest"`
-	PreviousCloseDate   Date    `json:"previous_close_date"`
-	PreviousClosePrice  float64 `json:"previous_close_price,string"`
-	Rho                 string  `json:"rho"`
-	Theta               string  `json:"theta"`
-	Vega                string  `json:"vega"`
-	Volume              int     `json:"volume"`
+	AdjustedMarkPrice   Decimal  `json:"adjusted_mark_price"`
+	AskPrice            Decimal  `json:"ask_price"`
+	AskSize             int      `json:"ask_size"`
+	BidPrice            Decimal  `json:"bid_price"`
+	BidSize             int      `json:"bid_size"`
+	BreakEvenPrice      Decimal  `json:"break_even_price"`
+	ChanceOfProfitLong  float64  `json:"chance_of_profit_long,string"`
+	ChanceOfProfitShort float64  `json:"chance_of_profit_short,string"`
+	Delta               *float64 `json:"delta,string"`
+	Gamma               *float64 `json:"gamma,string"`
+	HighPrice           Decimal  `json:"high_price"`
+	ImpliedVolatility   string   `json:"implied_volatility"`
+	Instrument          string   `json:"instrument"`
+	LastTradePrice      Decimal  `json:"last_trade_price"`
+	LastTradeSize       int      `json:"last_trade_size"`
+	LowPrice            Decimal  `json:"low_price"`
+	MarkPrice           Decimal  `json:"mark_price"`
+	OpenInterest        int      `json:"open_interest"`
+	PreviousCloseDate   Date     `json:"previous_close_date"`
+	PreviousClosePrice  Decimal  `json:"previous_close_price"`
+	Rho                 string   `json:"rho"`
+	Theta               string   `json:"theta"`
+	Vega                string   `json:"vega"`
+	Volume              int      `json:"volume"`
 }
 
 // OIsForDate filters OptionInstruments for expiration date.
@@ -240,7 +221,7 @@ func (c *Client) MarketData(ctx context.Context, opts ...*OptionInstrument) ([]*
 	rs := []*MarketData{}
 
 	for i := 0; i < n; i++ {
-		end := (i+1)*num + 1
+		end := (i + 1) * num
 		if end > len(is) {
 			end = len(is)
 		}
@@ -250,7 +231,7 @@ func (c *Client) MarketData(ctx context.Context, opts ...*OptionInstrument) ([]*
 		u.RawQuery = q.Encode()
 
 		var r struct{ Results []*MarketData }
-		if e := c.GetAndDecode(ctx, u.String(), &r); err != nil {
+		if e := c.GetAndDecode(ctx, u.String(), &r); e != nil {
 			err = multierror.Append(err, e)
 			continue
 		}
@@ -0,0 +1,123 @@
+package robinhood
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderOptionLegs(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+
+	exp := Date{time.Date(2021, 6, 18, 0, 0, 0, 0, time.UTC)}
+	option := func(id, typ, strike, mark string) *OptionInstrument {
+		srv.SetOptionMarketData(id, robinhoodtest.OptionMarketData{MarkPrice: mark})
+		return &OptionInstrument{
+			ID:             id,
+			URL:            srv.URL() + "options/instruments/" + id + "/",
+			Type:           typ,
+			StrikePrice:    MustParseDecimal(strike),
+			ExpirationDate: exp,
+			MinTicks:       MinTicks{AboveTick: MustParseDecimal("0.05"), BelowTick: MustParseDecimal("0.01"), CutoffPrice: NewDecimal(3, 0)},
+		}
+	}
+	p90 := option("p90", "put", "90", "0.50")
+	p95 := option("p95", "put", "95", "1.20")
+	c105 := option("c105", "call", "105", "1.10")
+	c110 := option("c110", "call", "110", "0.40")
+
+	legs, err := IronCondor(p90, p95, c105, c110)
+	require.NoError(t, err)
+	out, err := c.OrderOptionLegs(ctx, legs, OptionsOrderOpts{Type: Limit, Quantity: NewDecimal(2, 0), Price: MustParseDecimal("1.35"), TimeInForce: GFD})
+	require.NoError(t, err)
+	require.Equal(t, "credit", out.Direction)
+	require.Len(t, out.Legs, 4)
+	require.Equal(t, p90.URL, out.Legs[0].Instrument)
+	require.Equal(t, "buy", out.Legs[0].Side)
+	require.Equal(t, "sell", out.Legs[1].Side)
+	require.Equal(t, "open", out.Legs[2].PositionEffect)
+	require.Equal(t, "1", out.Legs[3].RatioQuantity.String())
+
+	// Closing legs must close positions held in the account.
+	legs, err = VerticalSpread(c105, c110)
+	require.NoError(t, err)
+	closing := ClosingLegs(legs)
+	require.Equal(t, SellToClose, closing[0].PositionEffect)
+	require.Equal(t, BuyToClose, closing[1].PositionEffect)
+	_, err = c.OrderOptionLegs(ctx, closing, OptionsOrderOpts{Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("0.7")})
+	require.EqualError(t, err, "invalid order position_effect SellToClose: 1 contracts of "+c105.URL+" needed but 0 held long")
+	srv.AddOptionPosition(robinhoodtest.DefaultAccountNumber, robinhoodtest.OptionPosition{
+		Quantity: "1",
+		Legs: []robinhoodtest.OptionPositionLeg{
+			{Option: c105.URL, PositionType: "long"},
+			{Option: c110.URL, PositionType: "short"},
+		},
+	})
+	out, err = c.OrderOptionLegs(ctx, closing, OptionsOrderOpts{Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("0.7")})
+	require.NoError(t, err)
+	require.Equal(t, "credit", out.Direction)
+	require.Equal(t, "sell", out.Legs[0].Side)
+	require.Equal(t, "close", out.Legs[0].PositionEffect)
+	require.Equal(t, "buy", out.Legs[1].Side)
+
+	legs, err = Straddle(c105, option("p105", "put", "105", "3.00"), Buy)
+	require.NoError(t, err)
+	legs[0].Ratio = NewDecimal(2, 0)
+	out, err = c.OrderOptionLegs(ctx, legs, OptionsOrderOpts{Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("5.2")})
+	require.NoError(t, err)
+	require.Equal(t, "debit", out.Direction)
+	require.Equal(t, "2", out.Legs[0].RatioQuantity.String())
+
+	// Prices are checked against the finest tick of the legs.
+	_, err = c.OrderOptionLegs(ctx, legs, OptionsOrderOpts{Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("5.21")})
+	require.EqualError(t, err, "invalid order price 5.21: not a multiple of 0.05")
+	_, err = c.OrderOptionLegs(ctx, []OptionLeg{{Option: c105, Side: Buy}, {Option: c105, Side: Sell}}, OptionsOrderOpts{Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(1, 0)})
+	require.EqualError(t, err, "invalid order legs "+c105.URL+": option is in more than one leg")
+	_, err = c.OrderOptionLegs(ctx, []OptionLeg{{Option: option("x", "call", "1", "")}}, OptionsOrderOpts{Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(1, 0)})
+	require.EqualError(t, err, "invalid order legs: leg 0 has no side")
+	_, err = c.OrderOptionLegs(ctx, []OptionLeg{{Option: c105, Side: Buy, PositionEffect: SellToOpen}}, OptionsOrderOpts{Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(1, 0)})
+	require.EqualError(t, err, "invalid order position_effect SellToOpen: conflicts with side Buy")
+
+	// A leg's side may be left to its position effect.
+	out, err = c.OrderOptionLegs(ctx, []OptionLeg{{Option: c105, PositionEffect: SellToOpen}, {Option: c110, PositionEffect: BuyToOpen}}, OptionsOrderOpts{Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("0.7")})
+	require.NoError(t, err)
+	require.Equal(t, "sell", out.Legs[0].Side)
+	require.Equal(t, "open", out.Legs[0].PositionEffect)
+	require.Equal(t, "credit", out.Direction)
+}
+
+func TestOptionStrategies(t *testing.T) {
+	day := func(d int) Date { return Date{time.Date(2021, 6, d, 0, 0, 0, 0, time.UTC)} }
+	option := func(typ string, strike int64, exp Date) *OptionInstrument {
+		return &OptionInstrument{Type: typ, StrikePrice: NewDecimal(strike, 0), ExpirationDate: exp}
+	}
+
+	_, err := VerticalSpread(option("call", 100, day(18)), option("put", 105, day(18)))
+	require.EqualError(t, err, "invalid order legs: vertical spread options must be of the same type")
+	_, err = VerticalSpread(option("call", 100, day(18)), option("call", 100, day(18)))
+	require.EqualError(t, err, "invalid order legs: vertical spread options must have different strikes")
+
+	legs, err := CalendarSpread(option("call", 100, day(18)), option("call", 100, day(25)))
+	require.NoError(t, err)
+	require.Equal(t, Sell, legs[0].Side)
+	require.Equal(t, Buy, legs[1].Side)
+	_, err = CalendarSpread(option("call", 100, day(25)), option("call", 100, day(18)))
+	require.EqualError(t, err, "invalid order legs: calendar spread far option must expire after near option")
+
+	_, err = Straddle(option("call", 100, day(18)), option("put", 95, day(18)), Sell)
+	require.EqualError(t, err, "invalid order legs: straddle options must have the same strike")
+	legs, err = Strangle(option("call", 105, day(18)), option("put", 95, day(18)), Sell)
+	require.NoError(t, err)
+	require.Equal(t, Sell, legs[1].Side)
+	_, err = Strangle(option("put", 105, day(18)), option("call", 95, day(18)), Sell)
+	require.EqualError(t, err, "invalid order legs: strangle needs a call and a put")
+
+	_, err = IronCondor(option("put", 90, day(18)), option("put", 95, day(18)), option("call", 94, day(18)), option("call", 110, day(18)))
+	require.EqualError(t, err, "invalid order legs: iron condor strikes must increase from long put to long call")
+}
@@ -1,9 +1,12 @@
 package robinhood
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/nikunjy/robinhood/robinhoodtest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -53,14 +56,97 @@ func TestOptionsOrder(t *testing.T) {
       }`)
 	var order OptionOrder
 	require.NoError(t, json.Unmarshal(data, &order))
-	require.EqualValues(t, order.CanceledQuantity, 0)
+	require.True(t, order.CanceledQuantity.IsZero())
 	require.Len(t, order.Legs, 1)
 	require.Len(t, order.Legs[0].Executions, 1)
 	execution := order.Legs[0].Executions[0]
-	require.EqualValues(t, execution.Price, 0.45)
+	require.Equal(t, "0.45", execution.Price.String())
+	require.Equal(t, "90", order.ProcessedPremium.String())
 	require.EqualValues(t, execution.ID, "b854d39c-5554-47b9-b32b-a5352ab5955e")
 	require.EqualValues(t, execution.SettlementDate, "2018-09-07")
 	require.EqualValues(t, order.Legs[0].Instrument, "https://api.robinhood.com/options/instruments/caadas-cb8xcx-4ooopo-lll-9bsdsds/")
 	require.EqualValues(t, order.Legs[0].ID, "cadaa42-assdsb0-4sdxd-as4f-959soso7666aa24")
 
 }
+
+func TestOptionsOrderLifecycle(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+	oi := &OptionInstrument{URL: srv.URL() + "options/instruments/x/"}
+
+	order, err := c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(2, 0), Price: NewDecimal(2, 0)})
+	require.NoError(t, err)
+	require.NotEmpty(t, order.ID)
+	require.NotEmpty(t, order.RefID)
+	require.Equal(t, Queued, order.State)
+	require.Equal(t, "2", order.Quantity.String())
+	require.Equal(t, oi.URL, order.Legs[0].Instrument)
+
+	require.NoError(t, order.Cancel(ctx))
+	require.NoError(t, order.Update(ctx))
+	require.Equal(t, Cancelled, order.State)
+	require.Error(t, order.Cancel(ctx))
+
+	order, err = c.OrderOptions(ctx, oi, OptionsOrderOpts{PositionEffect: SellToOpen, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(2, 0)})
+	require.NoError(t, err)
+	require.NoError(t, srv.FillOrder(order.ID, "1", "2"))
+	require.NoError(t, order.WaitUntilDone(ctx, time.Millisecond, nil))
+	require.Equal(t, Filled, order.State)
+}
+
+func TestOptionsOrderPositionEffect(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+	call := &OptionInstrument{URL: srv.URL() + "options/instruments/call/"}
+	put := &OptionInstrument{URL: srv.URL() + "options/instruments/put/"}
+	opts := func(e PositionEffect, qty int64) OptionsOrderOpts {
+		return OptionsOrderOpts{PositionEffect: e, Type: Limit, Quantity: NewDecimal(qty, 0), Price: NewDecimal(1, 0)}
+	}
+
+	// Writing a covered call sells to open, for a credit.
+	order, err := c.OrderOptions(ctx, call, opts(SellToOpen, 1))
+	require.NoError(t, err)
+	require.Equal(t, "credit", order.Direction)
+	require.Equal(t, "sell", order.Legs[0].Side)
+	require.Equal(t, "open", order.Legs[0].PositionEffect)
+
+	// Buying it back closes a short position, which must be held.
+	_, err = c.OrderOptions(ctx, call, opts(BuyToClose, 1))
+	require.EqualError(t, err, "invalid order position_effect BuyToClose: 1 contracts of "+call.URL+" needed but 0 held short")
+	require.True(t, IsValidationError(err))
+	srv.AddOptionPosition(robinhoodtest.DefaultAccountNumber, robinhoodtest.OptionPosition{
+		Quantity: "2",
+		Legs:     []robinhoodtest.OptionPositionLeg{{Option: call.URL, PositionType: "short"}},
+	})
+	srv.AddOptionPosition(robinhoodtest.DefaultAccountNumber, robinhoodtest.OptionPosition{
+		Quantity: "1",
+		Legs:     []robinhoodtest.OptionPositionLeg{{Option: put.URL, PositionType: "long"}},
+	})
+	order, err = c.OrderOptions(ctx, call, opts(BuyToClose, 2))
+	require.NoError(t, err)
+	require.Equal(t, "debit", order.Direction)
+	require.Equal(t, "buy", order.Legs[0].Side)
+	require.Equal(t, "close", order.Legs[0].PositionEffect)
+	_, err = c.OrderOptions(ctx, call, opts(BuyToClose, 3))
+	require.EqualError(t, err, "invalid order position_effect BuyToClose: 3 contracts of "+call.URL+" needed but 2 held short")
+	_, err = c.OrderOptions(ctx, call, opts(SellToClose, 1))
+	require.EqualError(t, err, "invalid order position_effect SellToClose: 1 contracts of "+call.URL+" needed but 0 held long")
+
+	// Without an explicit effect, a Sell closes a long position, and is
+	// placed without checking positions.
+	positions := countRequests(srv, "GET", "/options/aggregate_positions/")
+	require.NotZero(t, positions)
+	order, err = c.OrderOptions(ctx, put, OptionsOrderOpts{Side: Sell, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(1, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "close", order.Legs[0].PositionEffect)
+	_, err = c.OrderOptions(ctx, call, OptionsOrderOpts{Side: Sell, Type: Limit, Quantity: NewDecimal(5, 0), Price: NewDecimal(1, 0)})
+	require.NoError(t, err)
+	require.Equal(t, positions, countRequests(srv, "GET", "/options/aggregate_positions/"))
+
+	_, err = c.OrderOptions(ctx, put, OptionsOrderOpts{Side: Buy, PositionEffect: SellToClose, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(1, 0)})
+	require.EqualError(t, err, "invalid order position_effect SellToClose: conflicts with side Buy")
+}
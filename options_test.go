@@ -3,12 +3,15 @@ package robinhood
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/nikunjy/robinhood/robinhoodtest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMarketData(t *testing.T) {
@@ -47,3 +50,36 @@ func TestMarketData(t *testing.T) {
 	spew.Dump(is)
 	fmt.Printf("len(is) = %+v\n", len(is))
 }
+
+func TestMarketDataBatches(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+
+	var opts []*OptionInstrument
+	for i := 0; i < 61; i++ {
+		o := srv.AddOptionInstrument(robinhoodtest.OptionInstrument{ExpirationDate: "2021-06-18", Type: "call", StrikePrice: fmt.Sprint(i)})
+		srv.SetOptionMarketData(o.ID, robinhoodtest.OptionMarketData{MarkPrice: "1"})
+		opts = append(opts, &OptionInstrument{URL: o.URL})
+	}
+
+	// Batches of 30 instruments each hold every instrument once, including
+	// those at the batch boundaries.
+	for _, n := range []int{29, 30, 31, 60, 61} {
+		before := countRequests(srv, "GET", "/marketdata/options/")
+		mds, err := c.MarketData(ctx, opts[:n]...)
+		require.NoError(t, err)
+		require.Len(t, mds, n)
+		for i, md := range mds {
+			require.Equal(t, opts[i].URL, md.Instrument)
+		}
+		require.Equal(t, (n+29)/30, countRequests(srv, "GET", "/marketdata/options/")-before, "%d instruments", n)
+	}
+
+	// A failed batch is reported, and the other batches are still returned.
+	srv.AddFault(robinhoodtest.Fault{Path: "/marketdata/options/", Status: http.StatusBadRequest, Times: 1})
+	mds, err := c.MarketData(ctx, opts...)
+	require.Error(t, err)
+	require.Len(t, mds, 31)
+}
@@ -0,0 +1,122 @@
+package robinhood
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// OrderQuery filters the orders listed by QueryOrders, QueryCryptoOrders,
+// QueryOptionsOrders and NewOptionsOrdersQueryIterator. Zero fields match
+// every order.
+type OrderQuery struct {
+	// UpdatedSince matches orders last updated at or after this time.
+	UpdatedSince time.Time
+	// Instrument matches equity orders for the instrument with this URL.
+	Instrument string
+	// State matches orders in this state.
+	State OrderState
+	// Symbol matches equity orders for this ticker symbol, and options
+	// orders for this chain symbol.
+	Symbol string
+}
+
+// url returns the order list endpoint base with the query's parameters.
+func (q OrderQuery) url(base string) string {
+	v := url.Values{}
+	if !q.UpdatedSince.IsZero() {
+		v.Set("updated_at[gte]", q.UpdatedSince.UTC().Format(time.RFC3339Nano))
+	}
+	if q.Instrument != "" {
+		v.Set("instrument", q.Instrument)
+	}
+	if q.State != "" {
+		v.Set("state", string(q.State))
+	}
+	if q.Symbol != "" {
+		v.Set("symbol", q.Symbol)
+	}
+	if len(v) == 0 {
+		return base
+	}
+	return base + "?" + v.Encode()
+}
+
+// QueryOrders returns all equity orders matching q, most recent first.
+func (c *Client) QueryOrders(ctx context.Context, q OrderQuery) ([]OrderOutput, error) {
+	var orders []OrderOutput
+	err := c.getAll(ctx, q.url(EPOrders), &orders)
+	for i := range orders {
+		orders[i].client = c
+	}
+	return orders, err
+}
+
+// QueryCryptoOrders returns all crypto orders matching q, most recent first.
+// Instrument and Symbol do not apply to crypto orders.
+func (c *Client) QueryCryptoOrders(ctx context.Context, q OrderQuery) ([]CryptoOrderOutput, error) {
+	var orders []CryptoOrderOutput
+	err := c.getAll(ctx, q.url(EPCryptoOrders), &orders)
+	for i := range orders {
+		orders[i].client = c
+	}
+	return orders, err
+}
+
+// QueryOptionsOrders returns all options orders matching q, most recent
+// first. Instrument does not apply to options orders.
+func (c *Client) QueryOptionsOrders(ctx context.Context, q OrderQuery) ([]OptionOrder, error) {
+	var orders []OptionOrder
+	err := c.getAll(ctx, q.url(EPOptions+"orders/"), &orders)
+	for i := range orders {
+		orders[i].client = c
+	}
+	return orders, err
+}
+
+// SyncOrders returns the equity orders updated at or after since, and the
+// watermark to pass as since to the next call: the latest update time of
+// the returned orders, or since if there are none. An order updated exactly
+// at the watermark is returned again by the next call, so callers should
+// merge orders by ID.
+func (c *Client) SyncOrders(ctx context.Context, since time.Time) ([]OrderOutput, time.Time, error) {
+	orders, err := c.QueryOrders(ctx, OrderQuery{UpdatedSince: since})
+	if err != nil {
+		return nil, since, err
+	}
+	for _, o := range orders {
+		since = later(since, o.UpdatedAt)
+	}
+	return orders, since, nil
+}
+
+// SyncCryptoOrders is like SyncOrders, for crypto orders.
+func (c *Client) SyncCryptoOrders(ctx context.Context, since time.Time) ([]CryptoOrderOutput, time.Time, error) {
+	orders, err := c.QueryCryptoOrders(ctx, OrderQuery{UpdatedSince: since})
+	if err != nil {
+		return nil, since, err
+	}
+	for _, o := range orders {
+		since = later(since, o.UpdatedAt)
+	}
+	return orders, since, nil
+}
+
+// SyncOptionsOrders is like SyncOrders, for options orders.
+func (c *Client) SyncOptionsOrders(ctx context.Context, since time.Time) ([]OptionOrder, time.Time, error) {
+	orders, err := c.QueryOptionsOrders(ctx, OrderQuery{UpdatedSince: since})
+	if err != nil {
+		return nil, since, err
+	}
+	for _, o := range orders {
+		since = later(since, o.UpdatedAt)
+	}
+	return orders, since, nil
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
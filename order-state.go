@@ -0,0 +1,38 @@
+package robinhood
+
+import "time"
+
+// OrderState is the state of an order.
+type OrderState string
+
+// Well-known order states. Orders start out queued or unconfirmed and end up
+// in one of the terminal states: filled, cancelled, rejected or failed.
+const (
+	Queued          OrderState = "queued"
+	Unconfirmed     OrderState = "unconfirmed"
+	Confirmed       OrderState = "confirmed"
+	PartiallyFilled OrderState = "partially_filled"
+	Filled          OrderState = "filled"
+	Cancelled       OrderState = "cancelled"
+	Rejected        OrderState = "rejected"
+	Failed          OrderState = "failed"
+)
+
+// IsTerminal reports whether an order in state s is done, i.e. will never
+// change state again.
+func (s OrderState) IsTerminal() bool {
+	switch s {
+	case Filled, Cancelled, Rejected, Failed:
+		return true
+	}
+	return false
+}
+
+// An Execution is a single fill of an order.
+type Execution struct {
+	ID             string    `json:"id"`
+	Price          Decimal   `json:"price"`
+	Quantity       Decimal   `json:"quantity"`
+	SettlementDate string    `json:"settlement_date"`
+	Timestamp      time.Time `json:"timestamp"`
+}
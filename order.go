@@ -1,9 +1,7 @@
 package robinhood
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -19,6 +17,17 @@ func (o OrderSide) MarshalJSON() ([]byte, error) {
 	return []byte("\"" + strings.ToLower(o.String()) + "\""), nil
 }
 
+// UnmarshalJSON implements json.Unmarshaler
+func (o *OrderSide) UnmarshalJSON(bs []byte) error {
+	for _, v := range []OrderSide{Buy, Sell} {
+		if strings.EqualFold(string(bs), fmt.Sprintf("%q", v)) {
+			*o = v
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid order side %s", bs)
+}
+
 //go:generate stringer -type OrderSide
 // Buy/Sell
 const (
@@ -34,6 +43,17 @@ func (o OrderType) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("%q", strings.ToLower(o.String()))), nil
 }
 
+// UnmarshalJSON implements json.Unmarshaler
+func (o *OrderType) UnmarshalJSON(bs []byte) error {
+	for _, v := range []OrderType{Market, Limit} {
+		if strings.EqualFold(string(bs), fmt.Sprintf("%q", v)) {
+			*o = v
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid order type %s", bs)
+}
+
 //go:generate stringer -type OrderType
 // Well-known order types. Default is Market.
 const (
@@ -41,104 +61,308 @@ const (
 	Limit
 )
 
-// OrderOpts encapsulates differences between order types
+// OrderOpts encapsulates differences between order types. Type and
+// StopPrice together select the kind of order:
+//
+//	Market, no StopPrice: market order
+//	Limit, no StopPrice:  limit order at Price
+//	Market, StopPrice:    stop-market order triggered at StopPrice
+//	Limit, StopPrice:     stop-limit order at Price, triggered at StopPrice
+//
+// Setting TrailingPercent or TrailingAmount places a trailing stop-market
+// order instead, whose stop follows the best price by that much.
+//
+// Orders for a fraction of a share, or for a Notional dollar amount, must be
+// market orders without stops, and are always good for the day.
 type OrderOpts struct {
 	Side          OrderSide
 	Type          OrderType
-	Quantity      uint64
-	Price         float64
+	Quantity      Decimal
+	Price         Decimal
 	TimeInForce   TimeInForce
 	ExtendedHours bool
-	Stop, Force   bool
+
+	// Notional, if set instead of Quantity, buys or sells this many dollars
+	// worth of the instrument, which must be fractionally tradable.
+	Notional Decimal
+
+	// StopPrice is the price at which a stop order is triggered. For a
+	// trailing stop it is the initial stop price, and is worked out from
+	// the latest quote if zero.
+	StopPrice Decimal
+
+	// TrailingPercent and TrailingAmount make the order a trailing stop
+	// that trails the price by a percentage or a dollar amount. At most
+	// one may be set.
+	TrailingPercent, TrailingAmount Decimal
+
+	// Stop makes a stop order with StopPrice equal to Price.
+	//
+	// Deprecated: set StopPrice instead.
+	Stop bool
+
+	// Force overrides the day trade and day trade buying power checks.
+	Force bool
+
+	// RefID identifies the order; see NewRefID. If empty, a new one is
+	// generated.
+	RefID string
+
+	// Account, if set, places the order in this account instead of the
+	// client's selected Account.
+	Account *Account
+}
+
+// TrailingPeg is how far the stop price of a trailing stop order trails the
+// market price.
+type TrailingPeg struct {
+	// Type is "percentage" or "price".
+	Type       string   `json:"type"`
+	Percentage *Decimal `json:"percentage,omitempty"`
+	Price      *Money   `json:"price,omitempty"`
+}
+
+// Money is an amount in a currency.
+type Money struct {
+	Amount       Decimal `json:"amount"`
+	CurrencyCode string  `json:"currency_code"`
 }
 
 type apiOrder struct {
-	Account       string    `json:"account,omitempty"`
-	Instrument    string    `json:"instrument,omitempty"`
-	Symbol        string    `json:"symbol,omitempty"`
-	Type          string    `json:"type,omitempty"`
-	TimeInForce   string    `json:"time_in_force,omitempty"`
-	Trigger       string    `json:"trigger,omitempty"`
-	Price         float64   `json:"price,omitempty"`
-	StopPrice     float64   `json:"stop_price,omitempty"`
-	Quantity      uint64    `json:"quantity,omitempty"`
-	Side          OrderSide `json:"side,omitempty"`
-	ExtendedHours bool      `json:"extended_hours,omitempty"`
+	Account       string       `json:"account,omitempty"`
+	Instrument    string       `json:"instrument,omitempty"`
+	Symbol        string       `json:"symbol,omitempty"`
+	Type          string       `json:"type,omitempty"`
+	TimeInForce   string       `json:"time_in_force,omitempty"`
+	Trigger       string       `json:"trigger,omitempty"`
+	Price         *Decimal     `json:"price,omitempty"`
+	StopPrice     *Decimal     `json:"stop_price,omitempty"`
+	TrailingPeg   *TrailingPeg `json:"trailing_peg,omitempty"`
+	Quantity      Decimal      `json:"quantity"`
+	Side          OrderSide    `json:"side,omitempty"`
+	ExtendedHours bool         `json:"extended_hours,omitempty"`
+
+	DollarBasedAmount *Money `json:"dollar_based_amount,omitempty"`
 
 	OverrideDayTradeChecks bool `json:"override_day_trade_checks,omitempty"`
 	OverrideDtbpChecks     bool `json:"override_dtbp_checks,omitempty"`
+
+	RefID string `json:"ref_id,omitempty"`
 }
 
 // Order places an order for a given instrument. Cancellation of the given
 // context cancels only the _http request_ and not any orders that may have
 // been created regardless of the cancellation.
 func (c *Client) Order(ctx context.Context, i *Instrument, o OrderOpts) (*OrderOutput, error) {
+	acct, err := c.account(o.Account)
+	if err != nil {
+		return nil, err
+	}
+	if o.Stop && o.StopPrice.IsZero() {
+		o.StopPrice = o.Price
+	}
+	if err := o.checkStops(); err != nil {
+		return nil, err
+	}
+	qty, err := c.orderQuantity(ctx, i, o)
+	if err != nil {
+		return nil, err
+	}
+	fractional := !o.Notional.IsZero() || !qty.IsMultipleOf(wholeShare)
+	if fractional {
+		if o.Type != Market || !o.StopPrice.IsZero() || o.trailingPeg() != nil || o.ExtendedHours {
+			return nil, &ValidationError{Field: "type", Reason: "fractional orders must be market orders without stops during regular hours"}
+		}
+		o.TimeInForce = GFD
+	}
+
+	price, err := c.checkPrice(o.Price, i.PriceTick(o.Price), o.Type == Limit)
+	if err != nil {
+		return nil, err
+	}
+
 	a := apiOrder{
-		Account:       c.Account.URL,
+		Account:       acct.URL,
 		Instrument:    i.URL,
 		Symbol:        i.Symbol,
 		Type:          strings.ToLower(o.Type.String()),
 		TimeInForce:   strings.ToLower(o.TimeInForce.String()),
-		Quantity:      o.Quantity,
+		Quantity:      qty,
 		Side:          o.Side,
 		ExtendedHours: o.ExtendedHours,
-		Price:         o.Price,
+		Price:         decimalPtr(price),
 		Trigger:       "immediate",
+
+		OverrideDayTradeChecks: o.Force,
+		OverrideDtbpChecks:     o.Force,
+
+		RefID: refID(o.RefID),
+	}
+	if !o.Notional.IsZero() {
+		a.DollarBasedAmount = &Money{Amount: o.Notional, CurrencyCode: "USD"}
 	}
 
-	if o.Stop {
-		a.StopPrice = o.Price
+	if peg := o.trailingPeg(); peg != nil {
+		if o.StopPrice.IsZero() {
+			if o.StopPrice, err = c.trailingStopPrice(ctx, i, o); err != nil {
+				return nil, err
+			}
+		}
+		a.TrailingPeg = peg
+	}
+	if !o.StopPrice.IsZero() {
+		stop, err := c.checkIncrement("stop_price", o.StopPrice, i.PriceTick(o.StopPrice), Decimal.RoundToTick)
+		if err != nil {
+			return nil, err
+		}
+		a.StopPrice = &stop
 		a.Trigger = "stop"
 	}
 
-	bs, err := json.Marshal(a)
+	out := OrderOutput{}
+	err = c.postOrder(ctx, EPOrders, a.RefID, a, &out)
 	if err != nil {
-		return nil, err
+		return &out, err
 	}
 
-	post, err := http.NewRequest("POST", EPOrders, bytes.NewReader(bs))
+	out.client = c
+	return &out, nil
+}
+
+// trailingPeg returns the trailing peg of a trailing stop order, or nil.
+func (o OrderOpts) trailingPeg() *TrailingPeg {
+	switch {
+	case !o.TrailingPercent.IsZero():
+		return &TrailingPeg{Type: "percentage", Percentage: &o.TrailingPercent}
+	case !o.TrailingAmount.IsZero():
+		return &TrailingPeg{Type: "price", Price: &Money{Amount: o.TrailingAmount, CurrencyCode: "USD"}}
+	}
+	return nil
+}
+
+// checkStops checks that the stop and trailing options of an order are
+// consistent with each other and with its type.
+func (o OrderOpts) checkStops() error {
+	hundred := NewDecimal(100, 0)
+	switch {
+	case o.StopPrice.Sign() < 0:
+		return &ValidationError{Field: "stop_price", Value: o.StopPrice.String(), Reason: "must not be negative"}
+	case !o.TrailingPercent.IsZero() && !o.TrailingAmount.IsZero():
+		return &ValidationError{Field: "trailing_peg", Reason: "cannot trail by both a percentage and an amount"}
+	case !o.TrailingPercent.IsZero() && (o.TrailingPercent.Sign() < 0 || o.TrailingPercent.Cmp(hundred) >= 0):
+		return &ValidationError{Field: "trailing_peg", Value: o.TrailingPercent.String(), Reason: "percentage must be between 0 and 100"}
+	case o.TrailingAmount.Sign() < 0:
+		return &ValidationError{Field: "trailing_peg", Value: o.TrailingAmount.String(), Reason: "amount must be positive"}
+	case o.trailingPeg() != nil && o.Type != Market:
+		return &ValidationError{Field: "type", Reason: "trailing stops must be market orders"}
+	case o.ExtendedHours && (o.Type != Limit || !o.StopPrice.IsZero() || o.trailingPeg() != nil):
+		return &ValidationError{Field: "extended_hours", Reason: "only limit orders without a stop may trade in extended hours"}
+	case o.Type != Limit || o.StopPrice.IsZero():
+		return nil
+	}
+
+	// A stop-limit order whose limit is on the wrong side of its stop
+	// cannot fill once triggered.
+	if o.Side == Buy && o.Price.Cmp(o.StopPrice) < 0 {
+		return &ValidationError{Field: "price", Value: o.Price.String(), Reason: fmt.Sprintf("limit of a buy stop-limit order is below its stop price %s", o.StopPrice)}
+	}
+	if o.Side == Sell && o.Price.Cmp(o.StopPrice) > 0 {
+		return &ValidationError{Field: "price", Value: o.Price.String(), Reason: fmt.Sprintf("limit of a sell stop-limit order is above its stop price %s", o.StopPrice)}
+	}
+	return nil
+}
+
+// lastPrice returns the latest price of the instrument.
+func (c *Client) lastPrice(ctx context.Context, i *Instrument) (Decimal, error) {
+	qs, err := c.GetQuote(ctx, i.Symbol)
 	if err != nil {
-		return nil, fmt.Errorf("error creating POST http.Request: %w", err)
+		return Decimal{}, errors.Wrap(err, "error getting quote")
+	}
+	if len(qs) == 0 || qs[0].Price().IsZero() {
+		return Decimal{}, fmt.Errorf("no quote for %s", i.Symbol)
 	}
+	return qs[0].Price(), nil
+}
 
-	post.Header.Add("Content-Type", "application/json")
+// orderQuantity validates the quantity of an equity order, working it out
+// from the latest quote for Notional orders.
+func (c *Client) orderQuantity(ctx context.Context, i *Instrument, o OrderOpts) (Decimal, error) {
+	if o.Notional.IsZero() {
+		inc := wholeShare
+		if i.IsFractionallyTradable() {
+			inc = fractionalShare
+		}
+		return c.checkQuantity(o.Quantity, inc)
+	}
 
-	out := OrderOutput{}
-	err = c.DoAndDecode(ctx, post, &out)
+	switch {
+	case !o.Quantity.IsZero():
+		return Decimal{}, &ValidationError{Field: "notional", Value: o.Notional.String(), Reason: "cannot be set with a quantity"}
+	case o.Notional.Sign() < 0:
+		return Decimal{}, &ValidationError{Field: "notional", Value: o.Notional.String(), Reason: "must be positive"}
+	case !i.IsFractionallyTradable():
+		return Decimal{}, &ValidationError{Field: "notional", Value: o.Notional.String(), Reason: fmt.Sprintf("%s is not fractionally tradable", i.Symbol)}
+	}
+	last, err := c.lastPrice(ctx, i)
 	if err != nil {
-		return &out, err
+		return Decimal{}, err
+	}
+	// Truncate, so that the shares never cost more than Notional.
+	qty := o.Notional.QuoTrunc(last, fractionalShare.scale)
+	if qty.IsZero() {
+		return Decimal{}, &ValidationError{Field: "notional", Value: o.Notional.String(), Reason: fmt.Sprintf("buys no shares at %s", last)}
+	}
+	return qty, nil
+}
+
+// trailingStopPrice works out the initial stop price of a trailing stop
+// order from the latest quote for the instrument.
+func (c *Client) trailingStopPrice(ctx context.Context, i *Instrument, o OrderOpts) (Decimal, error) {
+	last, err := c.lastPrice(ctx, i)
+	if err != nil {
+		return Decimal{}, errors.Wrap(err, "error setting trailing stop")
 	}
 
-	out.client = c
-	return &out, nil
+	trail := o.TrailingAmount
+	if !o.TrailingPercent.IsZero() {
+		trail = last.Mul(o.TrailingPercent).Quo(NewDecimal(100, 0), 8)
+	}
+	if o.Side == Buy {
+		trail = trail.Neg()
+	}
+	stop := last.Sub(trail)
+	return stop.RoundToTick(i.PriceTick(stop)), nil
 }
 
 // OrderOutput is the response from the Order api
 type OrderOutput struct {
 	Meta
-	Account                string        `json:"account"`
-	AveragePrice           float64       `json:"average_price,string"`
-	CancelURL              string        `json:"cancel"`
-	CreatedAt              string        `json:"created_at"`
-	CumulativeQuantity     string        `json:"cumulative_quantity"`
-	Executions             []interface{} `json:"executions"`
-	ExtendedHours          bool          `json:"extended_hours"`
-	Fees                   string        `json:"fees"`
-	ID                     string        `json:"id"`
-	Instrument             string        `json:"instrument"`
-	LastTransactionAt      string        `json:"last_transaction_at"`
-	OverrideDayTradeChecks bool          `json:"override_day_trade_checks"`
-	OverrideDtbpChecks     bool          `json:"override_dtbp_checks"`
-	Position               string        `json:"position"`
-	Price                  float64       `json:"price,string"`
-	Quantity               string        `json:"quantity"`
-	RejectReason           string        `json:"reject_reason"`
-	Side                   string        `json:"side"`
-	State                  string        `json:"state"`
-	StopPrice              float64       `json:"stop_price,string"`
-	TimeInForce            string        `json:"time_in_force"`
-	Trigger                string        `json:"trigger"`
-	Type                   string        `json:"type"`
+	Account                string       `json:"account"`
+	AveragePrice           Decimal      `json:"average_price"`
+	CancelURL              string       `json:"cancel"`
+	CreatedAt              string       `json:"created_at"`
+	CumulativeQuantity     Decimal      `json:"cumulative_quantity"`
+	Executions             []Execution  `json:"executions"`
+	ExtendedHours          bool         `json:"extended_hours"`
+	Fees                   Decimal      `json:"fees"`
+	ID                     string       `json:"id"`
+	Instrument             string       `json:"instrument"`
+	LastTransactionAt      string       `json:"last_transaction_at"`
+	OverrideDayTradeChecks bool         `json:"override_day_trade_checks"`
+	OverrideDtbpChecks     bool         `json:"override_dtbp_checks"`
+	Position               string       `json:"position"`
+	Price                  Decimal      `json:"price"`
+	Quantity               Decimal      `json:"quantity"`
+	RefID                  string       `json:"ref_id"`
+	RejectReason           string       `json:"reject_reason"`
+	Side                   OrderSide    `json:"side"`
+	State                  OrderState   `json:"state"`
+	StopPrice              Decimal      `json:"stop_price"`
+	TimeInForce            string       `json:"time_in_force"`
+	TrailingPeg            *TrailingPeg `json:"trailing_peg"`
+	DollarBasedAmount      *Money       `json:"dollar_based_amount"`
+	Trigger                string       `json:"trigger"`
+	Type                   OrderType    `json:"type"`
 
 	client *Client
 }
@@ -186,35 +410,5 @@ func (c *Client) RecentOrders(ctx context.Context) ([]OrderOutput, error) {
 
 // AllOrders returns all orders made by this client.
 func (c *Client) AllOrders(ctx context.Context) ([]OrderOutput, error) {
-	var o struct {
-		Results []OrderOutput
-	}
-
-	url := EPOrders
-	for {
-		select {
-		case <-ctx.Done():
-			return o.Results, ctx.Err()
-		default:
-		}
-
-		var tmp struct {
-			Results []OrderOutput
-			Next    string
-		}
-		err := c.GetAndDecode(ctx, url, &tmp)
-
-		if err != nil {
-			return o.Results, err
-		}
-
-		url = tmp.Next
-		o.Results = append(o.Results, tmp.Results...)
-
-		if url == "" {
-			break
-		}
-	}
-
-	return o.Results, nil
+	return c.QueryOrders(ctx, OrderQuery{})
 }
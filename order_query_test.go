@@ -0,0 +1,140 @@
+package robinhood
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryOrders(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 1
+	c := dialTest(t, srv)
+	spy := &Instrument{URL: srv.URL() + "instruments/spy/", Symbol: "SPY"}
+	qqq := &Instrument{URL: srv.URL() + "instruments/qqq/", Symbol: "QQQ"}
+
+	var ids []string
+	for _, i := range []*Instrument{spy, qqq, spy} {
+		out, err := c.Order(ctx, i, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(10, 0)})
+		require.NoError(t, err)
+		ids = append(ids, out.ID)
+	}
+	require.NoError(t, srv.SetOrderState(ids[0], "cancelled"))
+
+	orders, err := c.QueryOrders(ctx, OrderQuery{Symbol: "SPY"})
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[2], ids[0]}, orderIDs(orders))
+
+	orders, err = c.QueryOrders(ctx, OrderQuery{Instrument: qqq.URL})
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[1]}, orderIDs(orders))
+
+	orders, err = c.QueryOrders(ctx, OrderQuery{State: Queued, Symbol: "SPY"})
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[2]}, orderIDs(orders))
+	require.NoError(t, orders[0].Cancel(ctx))
+
+	all, err := c.AllOrders(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+}
+
+func TestOptionsOrdersQueryIterator(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 1
+	c := dialTest(t, srv)
+	oi := &OptionInstrument{URL: srv.URL() + "options/instruments/x/"}
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		o, err := c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(2, 0)})
+		require.NoError(t, err)
+		ids = append(ids, o.ID)
+	}
+	require.NoError(t, srv.SetOrderState(ids[1], "cancelled"))
+
+	collect := func(it OptionsOrdersIterator) []string {
+		var got []string
+		for it.HasNext() {
+			orders, err := it.Next(ctx)
+			require.NoError(t, err)
+			for _, o := range orders {
+				got = append(got, o.ID)
+			}
+		}
+		return got
+	}
+	require.Equal(t, []string{ids[2], ids[1], ids[0]}, collect(c.NewOptionsOrdersIterator()))
+	require.Equal(t, []string{ids[2], ids[0]}, collect(c.NewOptionsOrdersQueryIterator(OrderQuery{State: Queued})))
+}
+
+func TestSyncOrders(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	now := time.Date(2021, 3, 1, 15, 0, 0, 0, time.UTC)
+	srv.Now = func() time.Time { return now }
+	c := dialTest(t, srv)
+	spy := &Instrument{URL: srv.URL() + "instruments/spy/", Symbol: "SPY"}
+	order := func() string {
+		now = now.Add(time.Minute)
+		out, err := c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(10, 0)})
+		require.NoError(t, err)
+		return out.ID
+	}
+
+	a, b := order(), order()
+	orders, mark, err := c.SyncOrders(ctx, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []string{b, a}, orderIDs(orders))
+	require.Equal(t, now, mark)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, srv.FillOrder(a, "1", "10"))
+	cc := order()
+	orders, mark, err = c.SyncOrders(ctx, mark.Add(time.Nanosecond))
+	require.NoError(t, err)
+	require.Equal(t, []string{cc, a}, orderIDs(orders))
+	require.Equal(t, now, mark)
+
+	orders, mark2, err := c.SyncOrders(ctx, mark.Add(time.Nanosecond))
+	require.NoError(t, err)
+	require.Empty(t, orders)
+	require.Equal(t, mark.Add(time.Nanosecond), mark2)
+
+	pair := CryptoCurrencyPair{ID: "btc", MinOrderPriceIncrement: MustParseDecimal("0.01"), CyrptoAssetCurrency: AssetCurrency{Increment: MustParseDecimal("0.00000001")}}
+	co, err := c.CryptoOrder(ctx, pair, CryptoOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(30000, 0)})
+	require.NoError(t, err)
+	crypto, cmark, err := c.SyncCryptoOrders(ctx, mark)
+	require.NoError(t, err)
+	require.Len(t, crypto, 1)
+	require.Equal(t, co.ID, crypto[0].ID)
+	require.Equal(t, now, cmark)
+
+	now = now.Add(time.Minute)
+	oi := &OptionInstrument{URL: srv.URL() + "options/instruments/x/"}
+	_, err = c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(2, 0)})
+	require.NoError(t, err)
+	options, omark, err := c.SyncOptionsOrders(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, options, 1)
+	require.Equal(t, now, omark)
+	options, _, err = c.SyncOptionsOrders(ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	require.Empty(t, options)
+}
+
+func orderIDs(orders []OrderOutput) []string {
+	ids := make([]string, len(orders))
+	for i, o := range orders {
+		ids[i] = o.ID
+	}
+	return ids
+}
@@ -0,0 +1,156 @@
+package robinhood
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopOrders(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.SetQuote(robinhoodtest.Quote{Symbol: "SPY", LastTradePrice: "100.00", LastExtendedHoursTradePrice: "100.00"})
+	c := dialTest(t, srv)
+	spy := &Instrument{URL: srv.URL() + "instruments/spy/", Symbol: "SPY"}
+
+	out, err := c.Order(ctx, spy, OrderOpts{Side: Sell, Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("94.5"), StopPrice: NewDecimal(95, 0)})
+	require.NoError(t, err)
+	require.Equal(t, Limit, out.Type)
+	require.Equal(t, "stop", out.Trigger)
+	require.Equal(t, "94.5", out.Price.String())
+	require.Equal(t, "95", out.StopPrice.String())
+
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Market, Quantity: NewDecimal(1, 0), StopPrice: NewDecimal(105, 0), Force: true})
+	require.NoError(t, err)
+	require.Equal(t, Market, out.Type)
+	require.Equal(t, "stop", out.Trigger)
+	require.True(t, out.Price.IsZero())
+	require.Equal(t, "105", out.StopPrice.String())
+	require.True(t, out.OverrideDayTradeChecks)
+	require.True(t, out.OverrideDtbpChecks)
+
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Sell, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(90, 0), Stop: true})
+	require.NoError(t, err)
+	require.Equal(t, "90", out.StopPrice.String())
+
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Sell, Type: Market, Quantity: NewDecimal(1, 0), TrailingPercent: MustParseDecimal("2.5")})
+	require.NoError(t, err)
+	require.Equal(t, "stop", out.Trigger)
+	require.Equal(t, "97.5", out.StopPrice.String())
+	require.Equal(t, &TrailingPeg{Type: "percentage", Percentage: decimalPtr(MustParseDecimal("2.5"))}, out.TrailingPeg)
+
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Market, Quantity: NewDecimal(1, 0), TrailingAmount: NewDecimal(3, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "103", out.StopPrice.String())
+	require.Equal(t, &TrailingPeg{Type: "price", Price: &Money{Amount: NewDecimal(3, 0), CurrencyCode: "USD"}}, out.TrailingPeg)
+
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Sell, Type: Market, Quantity: NewDecimal(1, 0), TrailingAmount: NewDecimal(3, 0), StopPrice: NewDecimal(96, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "96", out.StopPrice.String())
+	require.Equal(t, 2, countRequests(srv, "GET", "/quotes/"), "explicit stop prices need no quote")
+}
+
+func TestStopOrderValidation(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+	spy := &Instrument{URL: srv.URL() + "instruments/spy/", Symbol: "SPY"}
+
+	for _, tc := range []struct {
+		opts OrderOpts
+		err  string
+	}{
+		{OrderOpts{Type: Market, StopPrice: NewDecimal(-1, 0)}, "invalid order stop_price -1: must not be negative"},
+		{OrderOpts{Type: Market, TrailingPercent: NewDecimal(1, 0), TrailingAmount: NewDecimal(1, 0)}, "invalid order trailing_peg: cannot trail by both a percentage and an amount"},
+		{OrderOpts{Type: Market, TrailingPercent: NewDecimal(100, 0)}, "invalid order trailing_peg 100: percentage must be between 0 and 100"},
+		{OrderOpts{Type: Limit, Price: NewDecimal(1, 0), TrailingAmount: NewDecimal(1, 0)}, "invalid order type: trailing stops must be market orders"},
+		{OrderOpts{Type: Market, StopPrice: NewDecimal(1, 0), ExtendedHours: true}, "invalid order extended_hours: only limit orders without a stop may trade in extended hours"},
+		{OrderOpts{Side: Buy, Type: Limit, Price: NewDecimal(99, 0), StopPrice: NewDecimal(100, 0)}, "invalid order price 99: limit of a buy stop-limit order is below its stop price 100"},
+		{OrderOpts{Side: Sell, Type: Limit, Price: NewDecimal(101, 0), StopPrice: NewDecimal(100, 0)}, "invalid order price 101: limit of a sell stop-limit order is above its stop price 100"},
+		{OrderOpts{Side: Sell, Type: Market, StopPrice: MustParseDecimal("100.001")}, "invalid order stop_price 100.001: not a multiple of 0.01"},
+	} {
+		tc.opts.Quantity = NewDecimal(1, 0)
+		_, err := c.Order(ctx, spy, tc.opts)
+		require.EqualError(t, err, tc.err)
+	}
+	require.Equal(t, 0, countRequests(srv, "POST", "/orders/"))
+}
+
+func TestFractionalOrders(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.SetQuote(robinhoodtest.Quote{Symbol: "SPY", LastTradePrice: "300.00", LastExtendedHoursTradePrice: "300.00"})
+	c := dialTest(t, srv)
+	spy := &Instrument{URL: srv.URL() + "instruments/spy/", Symbol: "SPY", FractionalTradability: "tradable"}
+
+	out, err := c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Market, Quantity: MustParseDecimal("0.5")})
+	require.NoError(t, err)
+	require.Equal(t, "0.5", out.Quantity.String())
+	require.Equal(t, "gfd", out.TimeInForce)
+	require.Nil(t, out.DollarBasedAmount)
+
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Market, Notional: NewDecimal(10, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "0.033333", out.Quantity.String())
+	require.Equal(t, "gfd", out.TimeInForce)
+	require.Equal(t, &Money{Amount: NewDecimal(10, 0), CurrencyCode: "USD"}, out.DollarBasedAmount)
+
+	// 20 / 300 is 0.0666..., which would round up to more than $20 worth.
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Market, Notional: NewDecimal(20, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "0.066666", out.Quantity.String())
+
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(2, 0), Price: NewDecimal(300, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "gtc", out.TimeInForce)
+
+	whole := &Instrument{URL: srv.URL() + "instruments/spy/", Symbol: "SPY", FractionalTradability: "untradable"}
+	for _, tc := range []struct {
+		inst *Instrument
+		opts OrderOpts
+		err  string
+	}{
+		{whole, OrderOpts{Type: Market, Quantity: MustParseDecimal("0.5")}, "invalid order quantity 0.5: not a multiple of 1"},
+		{whole, OrderOpts{Type: Market, Notional: NewDecimal(10, 0)}, "invalid order notional 10: SPY is not fractionally tradable"},
+		{spy, OrderOpts{Type: Market, Notional: NewDecimal(10, 0), Quantity: NewDecimal(1, 0)}, "invalid order notional 10: cannot be set with a quantity"},
+		{spy, OrderOpts{Type: Market, Notional: MustParseDecimal("0.0001")}, "invalid order notional 0.0001: buys no shares at 300"},
+		{spy, OrderOpts{Type: Limit, Quantity: MustParseDecimal("0.5"), Price: NewDecimal(300, 0)}, "invalid order type: fractional orders must be market orders without stops during regular hours"},
+		{spy, OrderOpts{Type: Market, Quantity: MustParseDecimal("0.0000001")}, "invalid order quantity 0.0000001: not a multiple of 0.000001"},
+	} {
+		tc.opts.Side = Buy
+		_, err := c.Order(ctx, tc.inst, tc.opts)
+		require.EqualError(t, err, tc.err)
+	}
+	require.Equal(t, 4, countRequests(srv, "POST", "/orders/"))
+}
+
+func TestOrderOutputTypes(t *testing.T) {
+	var out OrderOutput
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"side": "sell", "type": "limit", "state": "partially_filled",
+		"executions": [{"id": "e1", "price": "10.01", "quantity": "2.00000", "settlement_date": "2021-01-05", "timestamp": "2021-01-01T15:00:00.000000Z"}]
+	}`), &out))
+	require.Equal(t, Sell, out.Side)
+	require.Equal(t, Limit, out.Type)
+	require.Equal(t, PartiallyFilled, out.State)
+	require.False(t, out.State.IsTerminal())
+	require.Equal(t, []Execution{{
+		ID:             "e1",
+		Price:          NewDecimal(1001, 2),
+		Quantity:       NewDecimal(2, 0),
+		SettlementDate: "2021-01-05",
+		Timestamp:      time.Date(2021, 1, 1, 15, 0, 0, 0, time.UTC),
+	}}, out.Executions)
+
+	for _, s := range []OrderState{Filled, Cancelled, Rejected, Failed} {
+		require.True(t, s.IsTerminal(), s)
+	}
+	require.Error(t, json.Unmarshal([]byte(`{"side": "short"}`), &out))
+}
@@ -0,0 +1,73 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// A PageIterator walks the pages of a list endpoint, following the "next"
+// link of each page until there is none.
+type PageIterator struct {
+	c    *Client
+	next string
+}
+
+// NewPageIterator returns a PageIterator over the pages of the list at url.
+func (c *Client) NewPageIterator(url string) *PageIterator {
+	return &PageIterator{c: c, next: url}
+}
+
+// HasNext reports whether there are pages left.
+func (p *PageIterator) HasNext() bool {
+	return p.next != ""
+}
+
+// Next fetches the next page and appends its results to the slice dest
+// points to. It returns io.EOF if there are no pages left.
+func (p *PageIterator) Next(ctx context.Context, dest interface{}) error {
+	if p.next == "" {
+		return io.EOF
+	}
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("robinhood: PageIterator.Next of %T, not a pointer to a slice", dest)
+	}
+
+	// Decode into a page of the slice's type, rather than into
+	// json.RawMessages, so that decode warnings carry the full path.
+	page := reflect.New(reflect.StructOf([]reflect.StructField{
+		{Name: "Results", Type: v.Elem().Type(), Tag: `json:"results"`},
+		{Name: "Next", Type: reflect.TypeOf(""), Tag: `json:"next"`},
+		{Name: "Previous", Type: reflect.TypeOf(""), Tag: `json:"previous"`},
+	}))
+	if err := p.c.GetAndDecode(ctx, p.next, page.Interface()); err != nil {
+		return err
+	}
+	p.next = page.Elem().Field(1).String()
+	v.Elem().Set(reflect.AppendSlice(v.Elem(), page.Elem().Field(0)))
+	return nil
+}
+
+// All fetches the remaining pages and appends their results to the slice
+// dest points to, stopping once the slice holds max items if max is
+// positive. Results already appended are kept if it fails.
+func (p *PageIterator) All(ctx context.Context, dest interface{}, max int) error {
+	for p.HasNext() {
+		if err := p.Next(ctx, dest); err != nil {
+			return err
+		}
+		if s := reflect.ValueOf(dest).Elem(); max > 0 && s.Len() >= max {
+			s.Set(s.Slice(0, max))
+			return nil
+		}
+	}
+	return nil
+}
+
+// getAll fetches every page of the list at url into the slice dest points
+// to.
+func (c *Client) getAll(ctx context.Context, url string, dest interface{}) error {
+	return c.NewPageIterator(url).All(ctx, dest, 0)
+}
@@ -0,0 +1,81 @@
+package robinhood
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageIterator(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 2
+	for _, n := range []string{"2", "3", "4", "5"} {
+		srv.AddAccount(robinhoodtest.Account{AccountNumber: n})
+	}
+	c := dialTest(t, srv)
+
+	it := c.NewPageIterator(EPAccounts)
+	var as []Account
+	require.True(t, it.HasNext())
+	require.NoError(t, it.Next(ctx, &as))
+	require.Len(t, as, 2)
+	require.NoError(t, it.All(ctx, &as, 0))
+	require.Len(t, as, 5)
+	require.False(t, it.HasNext())
+	require.Equal(t, io.EOF, it.Next(ctx, &as))
+
+	// All stops fetching pages once it has max items.
+	as = nil
+	before := countRequests(srv, "GET", "/accounts/")
+	require.NoError(t, c.NewPageIterator(EPAccounts).All(ctx, &as, 3))
+	require.Len(t, as, 3)
+	require.Equal(t, 2, countRequests(srv, "GET", "/accounts/")-before)
+
+	require.Error(t, c.NewPageIterator(EPAccounts).Next(ctx, as))
+}
+
+func TestListsFollowPages(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 1
+	srv.AddAccount(robinhoodtest.Account{AccountNumber: "2"})
+	for _, sym := range []string{"BTC", "ETH", "DOGE"} {
+		srv.AddCurrencyPair(robinhoodtest.CurrencyPair{Symbol: sym + "-USD", AssetCurrency: robinhoodtest.Currency{Code: sym}})
+	}
+	for _, sym := range []string{"SPY", "QQQ"} {
+		i := srv.AddInstrument(robinhoodtest.Instrument{Symbol: sym})
+		srv.AddPosition(robinhoodtest.DefaultAccountNumber, robinhoodtest.Position{Instrument: i.URL, Quantity: "1.0000"})
+	}
+	c := dialTest(t, srv)
+
+	as, err := c.GetAccounts(ctx)
+	require.NoError(t, err)
+	require.Len(t, as, 2)
+
+	pairs, err := c.GetCryptoCurrencyPairs(ctx)
+	require.NoError(t, err)
+	require.Len(t, pairs, 3)
+	doge, err := c.GetCryptoInstrument(ctx, "DOGE")
+	require.NoError(t, err)
+	require.Equal(t, "DOGE-USD", doge.Symbol)
+
+	ps, err := c.GetPositions(ctx)
+	require.NoError(t, err)
+	require.Len(t, ps, 2)
+
+	oi := &OptionInstrument{URL: srv.URL() + "options/instruments/x/"}
+	for i := 0; i < 3; i++ {
+		_, err := c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(1, 0)})
+		require.NoError(t, err)
+	}
+	oos, err := c.GetOptionsOrders(ctx)
+	require.NoError(t, err)
+	require.Len(t, oos, 3)
+	require.NoError(t, oos[2].Update(ctx))
+}
@@ -5,41 +5,55 @@ import "context"
 // Portfolio holds all information regarding the portfolio
 type Portfolio struct {
 	Account                                string  `json:"account"`
-	AdjustedEquityPreviousClose            float64 `json:"adjusted_equity_previous_close,string"`
-	Equity                                 float64 `json:"equity,string"`
-	EquityPreviousClose                    float64 `json:"equity_previous_close,string"`
-	ExcessMaintenance                      float64 `json:"excess_maintenance,string"`
-	ExcessMaintenanceWithUnclearedDeposits float64 `json:"excess_maintenance_with_uncleared_deposits,string"`
-	ExcessMargin                           float64 `json:"excess_margin,string"`
-	ExcessMarginWithUnclearedDeposits      float64 `json:"excess_margin_with_uncleared_deposits,string"`
-	ExtendedHoursEquity                    float64 `json:"extended_hours_equity,string"`
-	ExtendedHoursMarketValue               float64 `json:"extended_hours_market_value,string"`
-	LastCoreEquity                         float64 `json:"last_core_equity,string"`
-	LastCoreMarketValue                    float64 `json:"last_core_market_value,string"`
-	MarketValue                            float64 `json:"market_value,string"`
+	AdjustedEquityPreviousClose            Decimal `json:"adjusted_equity_previous_close"`
+	Equity                                 Decimal `json:"equity"`
+	EquityPreviousClose                    Decimal `json:"equity_previous_close"`
+	ExcessMaintenance                      Decimal `json:"excess_maintenance"`
+	ExcessMaintenanceWithUnclearedDeposits Decimal `json:"excess_maintenance_with_uncleared_deposits"`
+	ExcessMargin                           Decimal `json:"excess_margin"`
+	ExcessMarginWithUnclearedDeposits      Decimal `json:"excess_margin_with_uncleared_deposits"`
+	ExtendedHoursEquity                    Decimal `json:"extended_hours_equity"`
+	ExtendedHoursMarketValue               Decimal `json:"extended_hours_market_value"`
+	LastCoreEquity                         Decimal `json:"last_core_equity"`
+	LastCoreMarketValue                    Decimal `json:"last_core_market_value"`
+	MarketValue                            Decimal `json:"market_value"`
 	StartDate                              string  `json:"start_date"`
-	UnwithdrawableDeposits                 float64 `json:"unwithdrawable_deposits,string"`
-	UnwithdrawableGrants                   float64 `json:"unwithdrawable_grants,string"`
+	UnwithdrawableDeposits                 Decimal `json:"unwithdrawable_deposits"`
+	UnwithdrawableGrants                   Decimal `json:"unwithdrawable_grants"`
 	URL                                    string  `json:"url"`
-	WithdrawableAmount                     float64 `json:"withdrawable_amount,string"`
+	WithdrawableAmount                     Decimal `json:"withdrawable_amount"`
 }
 
 // CryptoPortfolio returns all the portfolio associated with a client's account
 type CryptoPortfolio struct {
 	AccountID                string  `json:"account_id"`
-	Equity                   float64 `json:"equity,string"`
-	ExtendedHoursEquity      float64 `json:"extended_hours_equity,string"`
-	ExtendedHoursMarketValue float64 `json:"extended_hours_market_value,string"`
+	Equity                   Decimal `json:"equity"`
+	ExtendedHoursEquity      Decimal `json:"extended_hours_equity"`
+	ExtendedHoursMarketValue Decimal `json:"extended_hours_market_value"`
 	ID                       string  `json:"id"`
-	MarketValue              float64 `json:"market_value,string"`
+	MarketValue              Decimal `json:"market_value"`
 }
 
 // GetPortfolios returns all the portfolios associated with a client's
 // credentials and accounts
 func (c *Client) GetPortfolios(ctx context.Context) ([]Portfolio, error) {
-	var p struct{ Results []Portfolio }
-	err := c.GetAndDecode(ctx, EPPortfolios, &p)
-	return p.Results, err
+	var ps []Portfolio
+	err := c.getAll(ctx, EPPortfolios, &ps)
+	return ps, err
+}
+
+// GetAccountPortfolio returns the portfolio of a single account. A nil
+// account means the client's selected Account.
+func (c *Client) GetAccountPortfolio(ctx context.Context, a *Account) (*Portfolio, error) {
+	a, err := c.account(a)
+	if err != nil {
+		return nil, err
+	}
+	var p Portfolio
+	if err := c.GetAndDecode(ctx, a.Portfolio, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
 }
 
 // GetCryptoPortfolios returns crypto portfolio info
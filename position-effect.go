@@ -0,0 +1,140 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// PositionEffect is whether an options order opens or closes a position, and
+// on which side.
+type PositionEffect int
+
+//go:generate stringer -type PositionEffect
+
+// The four position effects. The zero value leaves the effect to be worked
+// out from the order's side.
+const (
+	// BuyToOpen opens or adds to a long position.
+	BuyToOpen PositionEffect = iota + 1
+	// SellToOpen opens or adds to a short position, e.g. writing a covered
+	// call or a cash-secured put.
+	SellToOpen
+	// BuyToClose closes some or all of a short position.
+	BuyToClose
+	// SellToClose closes some or all of a long position.
+	SellToClose
+)
+
+// Side returns the side of an order with the position effect.
+func (e PositionEffect) Side() OrderSide {
+	if e == BuyToOpen || e == BuyToClose {
+		return Buy
+	}
+	return Sell
+}
+
+// IsClose reports whether the position effect closes a position.
+func (e PositionEffect) IsClose() bool {
+	return e == BuyToClose || e == SellToClose
+}
+
+// effect returns the position_effect the API expects: "open" or "close".
+func (e PositionEffect) effect() string {
+	if e.IsClose() {
+		return "close"
+	}
+	return "open"
+}
+
+// direction returns the direction of a single-leg order with the position
+// effect.
+func (e PositionEffect) direction() OptionDirection {
+	if e.Side() == Buy {
+		return Debit
+	}
+	return Credit
+}
+
+// openOn returns the effect that opens a position on side.
+func openOn(side OrderSide) PositionEffect {
+	if side == Buy {
+		return BuyToOpen
+	}
+	return SellToOpen
+}
+
+// closeOn returns the effect that closes a position on side.
+func closeOn(side OrderSide) PositionEffect {
+	if side == Buy {
+		return BuyToClose
+	}
+	return SellToClose
+}
+
+// checkEffect checks that e is a known position effect and agrees with side,
+// if both are set, and returns the side of the order.
+func checkEffect(e PositionEffect, side OrderSide) (OrderSide, error) {
+	if e < BuyToOpen || e > SellToClose {
+		return side, &ValidationError{Field: "position_effect", Value: e.String(), Reason: "unknown position effect"}
+	}
+	if side != 0 && side != e.Side() {
+		return side, &ValidationError{Field: "position_effect", Value: e.String(), Reason: fmt.Sprintf("conflicts with side %s", side)}
+	}
+	return e.Side(), nil
+}
+
+// checkCloses checks that acct holds enough contracts of each closing leg of
+// an order for qty units of legs: long contracts for SellToClose, short ones
+// for BuyToClose.
+func (c *Client) checkCloses(ctx context.Context, acct *Account, qty Decimal, legs []OptionLeg) error {
+	var closing []OptionLeg
+	for _, l := range legs {
+		if l.PositionEffect.IsClose() {
+			closing = append(closing, l)
+		}
+	}
+	if len(closing) == 0 {
+		return nil
+	}
+
+	ps, err := c.GetOptionPositions(ctx, ForAccount(acct), ExcludeZeroPositions())
+	if err != nil {
+		return errors.Wrap(err, "error getting option positions")
+	}
+	// held is the net number of contracts held of each option, negative
+	// if short.
+	held := map[string]Decimal{}
+	for _, p := range ps {
+		if acct.URL != "" && p.Account != "" && p.Account != acct.URL {
+			continue
+		}
+		for _, l := range p.Legs {
+			n := p.Quantity.Mul(NewDecimal(int64(l.RatioQuantity), 0))
+			if l.PositionType == Short {
+				n = n.Neg()
+			}
+			held[l.Option] = held[l.Option].Add(n)
+		}
+	}
+
+	for _, l := range closing {
+		need := qty.Mul(l.Ratio)
+		have, side := held[l.Option.URL], "long"
+		if l.PositionEffect == BuyToClose {
+			have, side = have.Neg(), "short"
+		}
+		if have.Sign() < 0 {
+			have = Decimal{}
+		}
+		if have.Cmp(need) < 0 {
+			return &ValidationError{
+				Field:  "position_effect",
+				Value:  l.PositionEffect.String(),
+				Reason: fmt.Sprintf("%s contracts of %s needed but %s held %s", need, l.Option.URL, have, side),
+			}
+		}
+	}
+	return nil
+}
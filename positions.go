@@ -35,20 +35,20 @@ func (c *CustomTime) UnmarshalJSON(data []byte) error {
 type Position struct {
 	Meta
 	Account                 string  `json:"account"`
-	AverageBuyPrice         float64 `json:"average_buy_price,string"`
+	AverageBuyPrice         Decimal `json:"average_buy_price"`
 	Instrument              string  `json:"instrument"`
-	IntradayAverageBuyPrice float64 `json:"intraday_average_buy_price,string"`
-	IntradayQuantity        float64 `json:"intraday_quantity,string"`
-	Quantity                float64 `json:"quantity,string"`
-	SharesHeldForBuys       float64 `json:"shares_held_for_buys,string"`
-	SharesHeldForSells      float64 `json:"shares_held_for_sells,string"`
+	IntradayAverageBuyPrice Decimal `json:"intraday_average_buy_price"`
+	IntradayQuantity        Decimal `json:"intraday_quantity"`
+	Quantity                Decimal `json:"quantity"`
+	SharesHeldForBuys       Decimal `json:"shares_held_for_buys"`
+	SharesHeldForSells      Decimal `json:"shares_held_for_sells"`
 }
 
 type OptionPostion struct {
 	Chain                    string        `json:"chain"`
-	AverageOpenPrice         float64       `json:"average_open_price,string"`
+	AverageOpenPrice         Decimal       `json:"average_open_price"`
 	Symbol                   string        `json:"symbol"`
-	Quantity                 float64       `json:"quantity,string"`
+	Quantity                 Decimal       `json:"quantity"`
 	Direction                string        `json:"direction"`
 	IntradayDirection        string        `json:"intraday_direction"`
 	TradeValueMultiplier     string        `json:"trade_value_multiplier"`
@@ -58,7 +58,7 @@ type OptionPostion struct {
 	IntradayQuantity         string        `json:"intraday_quantity"`
 	UpdatedAt                time.Time     `json:"updated_at,string"`
 	Id                       string        `json:"id"`
-	IntradayAverageOpenPrice float64       `json:"intraday_average_open_price,string"`
+	IntradayAverageOpenPrice Decimal       `json:"intraday_average_open_price"`
 	CreatedAt                time.Time     `json:"created_at,string"`
 }
 
@@ -76,12 +76,13 @@ type LegPosition struct {
 	Option         string       `json:"option"`
 	RatioQuantity  int          `json:"ratio_quantity"`
 	ExpirationDate CustomTime   `json:"expiration_date"`
-	StrikePrice    float64      `json:"strike_price,string"`
+	StrikePrice    Decimal      `json:"strike_price"`
 	OptionType     string       `json:"option_type"`
 }
 
 type getPositionConfig struct {
 	nonZero bool
+	account *Account
 }
 
 func (c *getPositionConfig) params() PositionParams {
@@ -89,6 +90,7 @@ func (c *getPositionConfig) params() PositionParams {
 	if c.nonZero {
 		params.NonZero = true
 	}
+	params.Account = c.account
 	return params
 }
 
@@ -106,6 +108,13 @@ func ExcludeZeroPositions() GetPositionsParamsOptions {
 	}
 }
 
+// ForAccount restricts positions to those held in the given account.
+func ForAccount(a *Account) GetPositionsParamsOptions {
+	return func(cfg *getPositionConfig) {
+		cfg.account = a
+	}
+}
+
 // GetPositions returns all the positions associated with an account.
 func (c *Client) GetOptionPositions(ctx context.Context, opts ...GetPositionsParamsOptions) ([]OptionPostion, error) {
 	cfg := newDefaultOptionsConfig()
@@ -128,29 +137,35 @@ func (c *Client) GetPositions(ctx context.Context, opts ...GetPositionsParamsOpt
 // endpoint.
 type PositionParams struct {
 	NonZero bool
+	// Account, if set, restricts positions to a single account.
+	Account *Account
 }
 
 // Encode returns the query string associated with the requested parameters
-func (p PositionParams) encode() string {
+func (p PositionParams) encode() url.Values {
 	v := url.Values{}
 	if p.NonZero {
 		v.Set("nonzero", "True")
 	}
-	return v.Encode()
+	return v
 }
 
 // GetPositionsParams returns all the positions associated with a count, but
 // passes the encoded PositionsParams object along to the RobinHood API as part
 // of the query string.
 func (c *Client) GetPositionsParams(ctx context.Context, p PositionParams) ([]Position, error) {
-	u, err := url.Parse(EPPositions)
+	ep := EPPositions
+	if p.Account != nil {
+		ep = p.Account.Positions
+	}
+	u, err := url.Parse(ep)
 	if err != nil {
 		return nil, err
 	}
-	u.RawQuery = p.encode()
+	u.RawQuery = p.encode().Encode()
 
-	var r struct{ Results []Position }
-	return r.Results, c.GetAndDecode(ctx, u.String(), &r)
+	var ps []Position
+	return ps, c.getAll(ctx, u.String(), &ps)
 }
 
 // GetOptionPositionsParams returns all the positions associated with a count, but
@@ -161,10 +176,43 @@ func (c *Client) GetOptionPositionsParams(ctx context.Context, p PositionParams)
 	if err != nil {
 		return nil, err
 	}
-	u.RawQuery = p.encode()
-	var r struct{ Results []OptionPostion }
-	if err := c.GetAndDecode(ctx, u.String(), &r); err != nil {
+	q := p.encode()
+	if p.Account != nil {
+		q.Set("account_numbers", p.Account.AccountNumber)
+	}
+	u.RawQuery = q.Encode()
+	var ps []OptionPostion
+	if err := c.getAll(ctx, u.String(), &ps); err != nil {
 		return nil, errors.Wrap(err, "error getting and decoding options")
 	}
-	return r.Results, nil
+	return ps, nil
+}
+
+// AccountPositions are the positions held in a single account.
+type AccountPositions struct {
+	Account   Account
+	Positions []Position
+}
+
+// GetAllAccountPositions returns the positions of every account associated
+// with the client's credentials.
+func (c *Client) GetAllAccountPositions(ctx context.Context, opts ...GetPositionsParamsOptions) ([]AccountPositions, error) {
+	as, err := c.GetAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy opts so that the caller's backing array is never written to.
+	o := make([]GetPositionsParamsOptions, len(opts)+1)
+	copy(o, opts)
+	out := make([]AccountPositions, 0, len(as))
+	for i := range as {
+		o[len(opts)] = ForAccount(&as[i])
+		ps, err := c.GetPositions(ctx, o...)
+		if err != nil {
+			return out, errors.Wrapf(err, "error getting positions of account %s", as[i].AccountNumber)
+		}
+		out = append(out, AccountPositions{Account: as[i], Positions: ps})
+	}
+	return out, nil
 }
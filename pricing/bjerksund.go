@@ -0,0 +1,90 @@
+package pricing
+
+import "math"
+
+// BjerksundStensland returns the price of the option as an American option
+// under the Bjerksund-Stensland (2002) approximation, whatever its Style. It
+// is never less than the option's intrinsic value or its BlackScholes price.
+func BjerksundStensland(p Params) float64 {
+	var v float64
+	if p.Type == Call {
+		v = bsCall(p.Spot, p.Strike, p.Expiry, p.Rate, p.Rate-p.Yield, p.Volatility)
+	} else {
+		// The put-call transformation: an American put is an American
+		// call with the spot and strike swapped, on an underlying whose
+		// carry is the put's interest rate less its yield.
+		v = bsCall(p.Strike, p.Spot, p.Expiry, p.Yield, p.Yield-p.Rate, p.Volatility)
+	}
+	return math.Max(v, math.Max(p.intrinsic(), BlackScholes(p)))
+}
+
+// bsCall is the Bjerksund-Stensland (2002) approximation of an American call
+// on an underlying s with cost of carry b.
+func bsCall(s, k, t, r, b, v float64) float64 {
+	if t <= 0 || v <= 0 || b >= r {
+		// Without volatility there is nothing to wait for; with a carry
+		// of at least the interest rate early exercise never pays.
+		return gbs(true, s, k, t, r, b, v)
+	}
+
+	t1 := (math.Sqrt(5) - 1) / 2 * t
+	v2 := v * v
+	beta := (0.5 - b/v2) + math.Sqrt((b/v2-0.5)*(b/v2-0.5)+2*r/v2)
+	bInf := beta / (beta - 1) * k
+	b0 := math.Max(k, r/(r-b)*k)
+	ht1 := -(b*t1 + 2*v*math.Sqrt(t1)) * k * k / ((bInf - b0) * b0)
+	ht2 := -(b*t + 2*v*math.Sqrt(t)) * k * k / ((bInf - b0) * b0)
+	i1 := b0 + (bInf-b0)*(1-math.Exp(ht1))
+	i2 := b0 + (bInf-b0)*(1-math.Exp(ht2))
+	if s >= i2 {
+		return s - k
+	}
+	alpha1 := (i1 - k) * math.Pow(i1, -beta)
+	alpha2 := (i2 - k) * math.Pow(i2, -beta)
+
+	phi := func(t, gamma, h, i float64) float64 {
+		return bsPhi(s, t, gamma, h, i, r, b, v)
+	}
+	psi := func(gamma, h float64) float64 {
+		return bsPsi(s, t, gamma, h, i2, i1, t1, r, b, v)
+	}
+	return alpha2*math.Pow(s, beta) - alpha2*phi(t1, beta, i2, i2) +
+		phi(t1, 1, i2, i2) - phi(t1, 1, i1, i2) -
+		k*phi(t1, 0, i2, i2) + k*phi(t1, 0, i1, i2) +
+		alpha1*phi(t1, beta, i1, i2) - alpha1*psi(beta, i1) +
+		psi(1, i1) - psi(1, k) -
+		k*psi(0, i1) + k*psi(0, k)
+}
+
+// bsPhi is the phi function of Bjerksund and Stensland.
+func bsPhi(s, t, gamma, h, i, r, b, v float64) float64 {
+	v2 := v * v
+	lambda := (-r + gamma*b + 0.5*gamma*(gamma-1)*v2) * t
+	d := -(math.Log(s/h) + (b+(gamma-0.5)*v2)*t) / (v * math.Sqrt(t))
+	kappa := 2*b/v2 + 2*gamma - 1
+	return math.Exp(lambda) * math.Pow(s, gamma) *
+		(cnd(d) - math.Pow(i/s, kappa)*cnd(d-2*math.Log(i/s)/(v*math.Sqrt(t))))
+}
+
+// bsPsi is the psi function of Bjerksund and Stensland (2002), which
+// accounts for the exercise boundary changing at t1.
+func bsPsi(s, t2, gamma, h, i2, i1, t1, r, b, v float64) float64 {
+	v2 := v * v
+	carry := b + (gamma-0.5)*v2
+	sd1, sd2 := v*math.Sqrt(t1), v*math.Sqrt(t2)
+	e1 := (math.Log(s/i1) + carry*t1) / sd1
+	e2 := (math.Log(i2*i2/(s*i1)) + carry*t1) / sd1
+	e3 := (math.Log(s/i1) - carry*t1) / sd1
+	e4 := (math.Log(i2*i2/(s*i1)) - carry*t1) / sd1
+	f1 := (math.Log(s/h) + carry*t2) / sd2
+	f2 := (math.Log(i2*i2/(s*h)) + carry*t2) / sd2
+	f3 := (math.Log(i1*i1/(s*h)) + carry*t2) / sd2
+	f4 := (math.Log(s*i1*i1/(h*i2*i2)) + carry*t2) / sd2
+	rho := math.Sqrt(t1 / t2)
+	lambda := -r + gamma*b + 0.5*gamma*(gamma-1)*v2
+	kappa := 2*b/v2 + 2*gamma - 1
+	return math.Exp(lambda*t2) * math.Pow(s, gamma) * (cbnd(-e1, -f1, rho) -
+		math.Pow(i2/s, kappa)*cbnd(-e2, -f2, rho) -
+		math.Pow(i1/s, kappa)*cbnd(-e3, -f3, -rho) +
+		math.Pow(i1/i2, kappa)*cbnd(-e4, -f4, -rho))
+}
@@ -0,0 +1,75 @@
+package pricing
+
+import "math"
+
+// BlackScholes returns the price of the option as a European option under
+// the Black-Scholes-Merton model, whatever its Style.
+func BlackScholes(p Params) float64 {
+	return gbs(p.Type == Call, p.Spot, p.Strike, p.Expiry, p.Rate, p.Rate-p.Yield, p.Volatility)
+}
+
+// gbs is the generalized Black-Scholes formula for an option on an
+// underlying s with cost of carry b.
+func gbs(call bool, s, k, t, r, b, v float64) float64 {
+	if t <= 0 {
+		if call {
+			return math.Max(s-k, 0)
+		}
+		return math.Max(k-s, 0)
+	}
+	fwd := s * math.Exp((b-r)*t)
+	disc := k * math.Exp(-r*t)
+	if v <= 0 {
+		if call {
+			return math.Max(fwd-disc, 0)
+		}
+		return math.Max(disc-fwd, 0)
+	}
+	d1 := (math.Log(s/k) + (b+v*v/2)*t) / (v * math.Sqrt(t))
+	d2 := d1 - v*math.Sqrt(t)
+	if call {
+		return fwd*cnd(d1) - disc*cnd(d2)
+	}
+	return disc*cnd(-d2) - fwd*cnd(-d1)
+}
+
+// blackScholesGreeks returns the exact Greeks of the option as a European
+// option.
+func blackScholesGreeks(p Params) Greeks {
+	t, v := p.Expiry, p.Volatility
+	if t <= 0 || v <= 0 {
+		// The price is piecewise linear in the spot, so only delta is
+		// defined.
+		var g Greeks
+		if p.intrinsic() > 0 {
+			g.Delta = 1
+			if p.Type == Put {
+				g.Delta = -1
+			}
+		}
+		return g
+	}
+
+	sqrtT := math.Sqrt(t)
+	d1 := (math.Log(p.Spot/p.Strike) + (p.Rate-p.Yield+v*v/2)*t) / (v * sqrtT)
+	d2 := d1 - v*sqrtT
+	divDisc := math.Exp(-p.Yield * t)
+	disc := p.Strike * math.Exp(-p.Rate*t)
+
+	g := Greeks{
+		Gamma: divDisc * pdf(d1) / (p.Spot * v * sqrtT),
+		Vega:  p.Spot * divDisc * pdf(d1) * sqrtT / 100,
+	}
+	decay := -p.Spot * divDisc * pdf(d1) * v / (2 * sqrtT)
+	if p.Type == Call {
+		g.Delta = divDisc * cnd(d1)
+		g.Theta = decay - p.Rate*disc*cnd(d2) + p.Yield*p.Spot*divDisc*cnd(d1)
+		g.Rho = t * disc * cnd(d2) / 100
+	} else {
+		g.Delta = -divDisc * cnd(-d1)
+		g.Theta = decay + p.Rate*disc*cnd(-d2) - p.Yield*p.Spot*divDisc*cnd(-d1)
+		g.Rho = -t * disc * cnd(-d2) / 100
+	}
+	g.Theta /= daysPerYear
+	return g
+}
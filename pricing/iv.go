@@ -0,0 +1,69 @@
+package pricing
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Bounds of the volatilities ImpliedVolatility searches.
+const (
+	minVolatility = 1e-4
+	maxVolatility = 10.0
+)
+
+// ErrNoVolatility is returned by ImpliedVolatility when no volatility gives
+// the option the price it is asked to match: the price is below the
+// option's value at zero volatility, or implausibly high.
+var ErrNoVolatility = errors.New("no volatility matches price")
+
+// ImpliedVolatility returns the volatility at which the option's Price is
+// price. The Volatility of p is ignored.
+func (p Params) ImpliedVolatility(price float64) (float64, error) {
+	at := func(v float64) float64 {
+		q := p
+		q.Volatility = v
+		return q.Price() - price
+	}
+
+	lo, hi := minVolatility, maxVolatility
+	flo, fhi := at(lo), at(hi)
+	switch {
+	case math.IsNaN(price) || price <= 0:
+		return 0, errors.Wrapf(ErrNoVolatility, "price %g", price)
+	case flo > 0:
+		return 0, errors.Wrapf(ErrNoVolatility, "price %g is below the %s's value %g", price, p.Type, flo+price)
+	case fhi < 0:
+		return 0, errors.Wrapf(ErrNoVolatility, "price %g is above the %s's value %g", price, p.Type, fhi+price)
+	}
+
+	// Newton's method, falling back to bisection whenever a step would
+	// leave the bracket [lo, hi], which always contains the root since the
+	// price rises with volatility.
+	v := 0.3
+	for i := 0; i < 100; i++ {
+		q := p
+		q.Volatility = v
+		f := q.Price() - price
+		if math.Abs(f) < 1e-10 {
+			return v, nil
+		}
+		if f < 0 {
+			lo = v
+		} else {
+			hi = v
+		}
+		if hi-lo < 1e-10 {
+			break
+		}
+		next := v
+		if vega := q.Greeks().Vega * 100; vega > 0 {
+			next = v - f/vega
+		}
+		if next <= lo || next >= hi || next == v {
+			next = (lo + hi) / 2
+		}
+		v = next
+	}
+	return v, nil
+}
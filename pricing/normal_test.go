@@ -0,0 +1,44 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cbndSimpson integrates P(X < x, Y < y) numerically, as
+// the integral up to x of pdf(u) cnd((y - rho u) / sqrt(1 - rho^2)).
+func cbndSimpson(x, y, rho float64) float64 {
+	const n = 20000
+	lo := -10.0
+	h := (x - lo) / n
+	f := func(u float64) float64 {
+		return pdf(u) * cnd((y-rho*u)/math.Sqrt(1-rho*rho))
+	}
+	sum := f(lo) + f(x)
+	for i := 1; i < n; i++ {
+		w := 2.0
+		if i%2 == 1 {
+			w = 4
+		}
+		sum += w * f(lo+float64(i)*h)
+	}
+	return sum * h / 3
+}
+
+func TestCBND(t *testing.T) {
+	for _, rho := range []float64{-0.99, -0.93, -0.8, -0.5, -0.1, 0, 0.2, 0.5, 0.8, 0.95, 0.99} {
+		// The orthant probability has a closed form.
+		require.InDelta(t, 0.25+math.Asin(rho)/(2*math.Pi), cbnd(0, 0, rho), 1e-12, "rho %g", rho)
+
+		for _, xy := range [][2]float64{{-1.5, 0.3}, {0.7, 1.2}, {2, -0.4}, {-0.2, -2.5}} {
+			x, y := xy[0], xy[1]
+			want := cbndSimpson(x, y, rho)
+			require.InDelta(t, want, cbnd(x, y, rho), 1e-9, "cbnd(%g, %g, %g)", x, y, rho)
+			require.InDelta(t, cbnd(x, y, rho), cbnd(y, x, rho), 1e-12)
+		}
+	}
+	require.InDelta(t, cnd(0.4)*cnd(-1.1), cbnd(0.4, -1.1, 0), 1e-15)
+	require.InDelta(t, 0.975, cnd(1.959963984540054), 1e-12)
+}
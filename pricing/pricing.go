@@ -0,0 +1,131 @@
+// Package pricing computes theoretical prices, Greeks and implied
+// volatilities of equity options, using the Black-Scholes-Merton model for
+// European options and the Bjerksund-Stensland (2002) approximation for
+// American ones.
+//
+// It needs no network access: ParamsFor and Evaluate work from the
+// OptionInstrument, Quote and MarketData already fetched with a
+// robinhood.Client, to fill in Greeks the API leaves null or to check the
+// ones it returns.
+package pricing
+
+import "math"
+
+// OptionType is whether an option is a call or a put.
+type OptionType int
+
+// The two option types.
+const (
+	Call OptionType = iota
+	Put
+)
+
+func (t OptionType) String() string {
+	if t == Put {
+		return "put"
+	}
+	return "call"
+}
+
+// Style is when an option can be exercised.
+type Style int
+
+// The two exercise styles. Listed US equity options are American.
+const (
+	// European options can only be exercised at expiry.
+	European Style = iota
+	// American options can be exercised at any time up to expiry.
+	American
+)
+
+// Params describes an option and the market it is priced in.
+type Params struct {
+	Type  OptionType
+	Style Style
+
+	// Spot is the price of the underlying.
+	Spot float64
+	// Strike is the strike price of the option.
+	Strike float64
+	// Expiry is the time to expiry in years.
+	Expiry float64
+	// Rate is the continuously compounded risk-free interest rate, e.g.
+	// 0.05 for 5%.
+	Rate float64
+	// Yield is the continuous dividend yield of the underlying.
+	Yield float64
+	// Volatility is the annualized volatility of the underlying, e.g. 0.2
+	// for 20%.
+	Volatility float64
+}
+
+// Greeks are the sensitivities of an option's price, in the units the
+// Robinhood API reports them in.
+type Greeks struct {
+	// Delta is the change in price per $1 rise in the underlying.
+	Delta float64
+	// Gamma is the change in Delta per $1 rise in the underlying.
+	Gamma float64
+	// Theta is the change in price per calendar day that passes.
+	Theta float64
+	// Vega is the change in price per percentage point rise in volatility.
+	Vega float64
+	// Rho is the change in price per percentage point rise in the
+	// interest rate.
+	Rho float64
+}
+
+// daysPerYear is the number of calendar days in a year, which Expiry and
+// Theta are measured in.
+const daysPerYear = 365
+
+// Price returns the theoretical price of the option: BlackScholes for a
+// European option, BjerksundStensland for an American one.
+func (p Params) Price() float64 {
+	if p.Style == American {
+		return BjerksundStensland(p)
+	}
+	return BlackScholes(p)
+}
+
+// Greeks returns the Greeks of the option. They are exact for a European
+// option, and worked out by finite differences of BjerksundStensland for an
+// American one.
+func (p Params) Greeks() Greeks {
+	if p.Style != American {
+		return blackScholesGreeks(p)
+	}
+
+	var g Greeks
+	price := p.Price()
+	up, down := p, p
+	ds := p.Spot * 1e-3
+	up.Spot += ds
+	down.Spot -= ds
+	pu, pd := up.Price(), down.Price()
+	g.Delta = (pu - pd) / (2 * ds)
+	g.Gamma = (pu - 2*price + pd) / (ds * ds)
+
+	up, down = p, p
+	up.Volatility += 0.005
+	down.Volatility = math.Max(p.Volatility-0.005, 0)
+	g.Vega = (up.Price() - down.Price()) / (up.Volatility - down.Volatility) / 100
+
+	up, down = p, p
+	up.Rate += 1e-4
+	down.Rate -= 1e-4
+	g.Rho = (up.Price() - down.Price()) / 2e-4 / 100
+
+	later := p
+	later.Expiry = math.Max(p.Expiry-1.0/daysPerYear, 0)
+	g.Theta = later.Price() - price
+	return g
+}
+
+// intrinsic returns the value of the option if it were exercised now.
+func (p Params) intrinsic() float64 {
+	if p.Type == Put {
+		return math.Max(p.Strike-p.Spot, 0)
+	}
+	return math.Max(p.Spot-p.Strike, 0)
+}
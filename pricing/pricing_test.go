@@ -0,0 +1,158 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// binomial prices p on a Cox-Ross-Rubinstein tree of n steps.
+func binomial(p Params, n int) float64 {
+	dt := p.Expiry / float64(n)
+	u := math.Exp(p.Volatility * math.Sqrt(dt))
+	d := 1 / u
+	pu := (math.Exp((p.Rate-p.Yield)*dt) - d) / (u - d)
+	disc := math.Exp(-p.Rate * dt)
+
+	payoff := func(s float64) float64 {
+		if p.Type == Put {
+			return math.Max(p.Strike-s, 0)
+		}
+		return math.Max(s-p.Strike, 0)
+	}
+	v := make([]float64, n+1)
+	for i := range v {
+		v[i] = payoff(p.Spot * math.Pow(u, float64(n-i)) * math.Pow(d, float64(i)))
+	}
+	for step := n - 1; step >= 0; step-- {
+		for i := 0; i <= step; i++ {
+			v[i] = disc * (pu*v[i] + (1-pu)*v[i+1])
+			if p.Style == American {
+				v[i] = math.Max(v[i], payoff(p.Spot*math.Pow(u, float64(step-i))*math.Pow(d, float64(i))))
+			}
+		}
+	}
+	return v[0]
+}
+
+func TestBlackScholes(t *testing.T) {
+	p := Params{Spot: 100, Strike: 100, Expiry: 1, Rate: 0.05, Volatility: 0.2}
+	require.InDelta(t, 10.450583572185565, p.Price(), 1e-9)
+	p.Type = Put
+	require.InDelta(t, 5.573526022256971, p.Price(), 1e-9)
+
+	// Put-call parity with a dividend yield.
+	p = Params{Spot: 42, Strike: 40, Expiry: 0.5, Rate: 0.1, Yield: 0.03, Volatility: 0.2}
+	call := p.Price()
+	p.Type = Put
+	put := p.Price()
+	require.InDelta(t, 42*math.Exp(-0.03*0.5)-40*math.Exp(-0.1*0.5), call-put, 1e-12)
+
+	// At expiry, or without volatility, an option is worth its intrinsic
+	// or discounted forward value.
+	require.Equal(t, 0.0, Params{Type: Put, Spot: 42, Strike: 40}.Price())
+	require.Equal(t, 2.0, Params{Spot: 42, Strike: 40}.Price())
+	require.InDelta(t, 42-40*math.Exp(-0.1), Params{Spot: 42, Strike: 40, Expiry: 1, Rate: 0.1}.Price(), 1e-12)
+}
+
+func TestBjerksundStensland(t *testing.T) {
+	for _, p := range []Params{
+		{Type: Put, Spot: 90, Strike: 100, Expiry: 0.5, Rate: 0.08, Volatility: 0.25},
+		{Type: Put, Spot: 100, Strike: 100, Expiry: 0.5, Rate: 0.08, Volatility: 0.25},
+		{Type: Put, Spot: 110, Strike: 100, Expiry: 0.5, Rate: 0.08, Volatility: 0.25},
+		{Type: Put, Spot: 40, Strike: 45, Expiry: 2, Rate: 0.05, Yield: 0.01, Volatility: 0.4},
+		{Type: Call, Spot: 90, Strike: 100, Expiry: 1, Rate: 0.03, Yield: 0.07, Volatility: 0.3},
+		{Type: Call, Spot: 110, Strike: 100, Expiry: 1, Rate: 0.03, Yield: 0.07, Volatility: 0.3},
+		{Type: Call, Spot: 100, Strike: 100, Expiry: 0.1, Rate: 0.02, Yield: 0.04, Volatility: 0.15},
+	} {
+		p.Style = American
+		want := binomial(p, 2000)
+		got := p.Price()
+		// The approximation slightly undervalues the early exercise
+		// premium.
+		require.True(t, got <= want+0.005 && got >= 0.97*want, "%+v: want %g, got %g", p, want, got)
+		require.True(t, got >= BlackScholes(p), "%+v", p)
+	}
+
+	// Deep in the money, an American put is exercised at once.
+	p := Params{Type: Put, Style: American, Spot: 50, Strike: 100, Expiry: 1, Rate: 0.1, Volatility: 0.2}
+	require.Equal(t, 50.0, p.Price())
+	require.True(t, BlackScholes(p) < 50)
+
+	// Without dividends an American call is worth the same as a European
+	// one.
+	p = Params{Type: Call, Style: American, Spot: 95, Strike: 100, Expiry: 1, Rate: 0.05, Volatility: 0.3}
+	require.Equal(t, BlackScholes(p), p.Price())
+}
+
+func TestGreeks(t *testing.T) {
+	for _, p := range []Params{
+		{Type: Call, Spot: 100, Strike: 105, Expiry: 0.5, Rate: 0.04, Yield: 0.01, Volatility: 0.3},
+		{Type: Put, Spot: 100, Strike: 95, Expiry: 0.25, Rate: 0.04, Yield: 0.01, Volatility: 0.45},
+	} {
+		g := p.Greeks()
+
+		// Check the exact Greeks against the finite differences used for
+		// American options.
+		bump := func(f func(*Params), h float64) float64 {
+			up, down := p, p
+			f(&up)
+			return (up.Price() - down.Price()) / h
+		}
+		require.InDelta(t, bump(func(q *Params) { q.Spot += 1e-4 }, 1e-4), g.Delta, 1e-4)
+		require.InDelta(t, bump(func(q *Params) { q.Volatility += 1e-6 }, 1e-4), g.Vega, 1e-4)
+		require.InDelta(t, bump(func(q *Params) { q.Rate += 1e-6 }, 1e-4), g.Rho, 1e-4)
+		require.InDelta(t, bump(func(q *Params) { q.Expiry -= 1e-6 }, 1e-6*daysPerYear), g.Theta, 1e-4)
+		up := p
+		up.Spot += 1e-3
+		require.InDelta(t, (up.Greeks().Delta-g.Delta)/1e-3, g.Gamma, 1e-4)
+
+		a := p
+		a.Style = American
+		if p.Type == Call {
+			// Without early exercise the American approximation is
+			// the European price, so its Greeks must match.
+			a.Yield = 0
+			p.Yield = 0
+			g = p.Greeks()
+		}
+		ag := a.Greeks()
+		if p.Type == Call {
+			require.InDelta(t, g.Delta, ag.Delta, 1e-4)
+			require.InDelta(t, g.Gamma, ag.Gamma, 1e-4)
+			require.InDelta(t, g.Vega, ag.Vega, 1e-4)
+			require.InDelta(t, g.Rho, ag.Rho, 1e-4)
+			require.InDelta(t, g.Theta, ag.Theta, 1e-3)
+		} else {
+			require.True(t, ag.Delta < g.Delta && ag.Delta > -1, "%+v", ag)
+			require.True(t, ag.Gamma > 0 && ag.Vega > 0 && ag.Rho < 0 && ag.Theta < 0, "%+v", ag)
+		}
+	}
+}
+
+func TestImpliedVolatility(t *testing.T) {
+	for _, p := range []Params{
+		{Type: Call, Spot: 100, Strike: 110, Expiry: 0.2, Rate: 0.03, Volatility: 0.25},
+		{Type: Put, Spot: 100, Strike: 110, Expiry: 0.2, Rate: 0.03, Volatility: 0.6},
+		{Type: Put, Style: American, Spot: 100, Strike: 90, Expiry: 1, Rate: 0.05, Yield: 0.02, Volatility: 0.35},
+		{Type: Call, Style: American, Spot: 100, Strike: 80, Expiry: 0.05, Rate: 0.05, Yield: 0.04, Volatility: 1.2},
+	} {
+		price := p.Price()
+		q := p
+		q.Volatility = 0
+		iv, err := q.ImpliedVolatility(price)
+		require.NoError(t, err)
+		require.InDelta(t, p.Volatility, iv, 1e-6, "%+v", p)
+	}
+
+	p := Params{Type: Put, Style: American, Spot: 100, Strike: 110, Expiry: 0.5, Rate: 0.05}
+	_, err := p.ImpliedVolatility(9.5)
+	require.Equal(t, ErrNoVolatility, errors.Cause(err))
+	require.EqualError(t, err, "price 9.5 is below the put's value 10: no volatility matches price")
+	_, err = p.ImpliedVolatility(200)
+	require.Equal(t, ErrNoVolatility, errors.Cause(err))
+	_, err = p.ImpliedVolatility(0)
+	require.Equal(t, ErrNoVolatility, errors.Cause(err))
+}
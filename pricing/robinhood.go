@@ -0,0 +1,162 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/nikunjy/robinhood"
+	"github.com/pkg/errors"
+)
+
+// expiryHour is the hour, New York time, at which listed options stop
+// trading on their expiration date.
+const expiryHour = robinhood.HrClose
+
+// ParamsFor returns the Params of the American option oi at now, on an
+// underlying priced at the latest price of q, with the given risk-free rate
+// and dividend yield. Its Volatility is left zero; see Evaluate.
+func ParamsFor(oi *robinhood.OptionInstrument, q robinhood.Quote, now time.Time, rate, yield float64) (Params, error) {
+	p := Params{
+		Style:  American,
+		Strike: oi.StrikePrice.Float64(),
+		Expiry: Expiry(oi.ExpirationDate, now),
+		Rate:   rate,
+		Yield:  yield,
+	}
+	switch oi.Type {
+	case "call":
+		p.Type = Call
+	case "put":
+		p.Type = Put
+	default:
+		return p, fmt.Errorf("unknown option type %q", oi.Type)
+	}
+
+	spot := q.Price()
+	if spot.IsZero() {
+		spot = q.LastTradePrice
+	}
+	if p.Spot = spot.Float64(); p.Spot <= 0 {
+		return p, fmt.Errorf("no price for %s", q.Symbol)
+	}
+	if p.Strike <= 0 {
+		return p, fmt.Errorf("invalid strike price %s", oi.StrikePrice)
+	}
+	return p, nil
+}
+
+// Expiry returns the time in years from now until the market closes on the
+// expiration date d, or zero if it has passed.
+func Expiry(d robinhood.Date, now time.Time) float64 {
+	end := time.Date(d.Year(), d.Month(), d.Day(), expiryHour, 0, 0, 0, newYork())
+	if !end.After(now) {
+		return 0
+	}
+	return end.Sub(now).Hours() / 24 / daysPerYear
+}
+
+// newYork returns the America/New_York time zone, or Eastern Standard Time
+// if the time zone database is missing.
+func newYork() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}
+
+// Result is the theoretical value of an option at a volatility.
+type Result struct {
+	Greeks
+
+	// Price is the price of the option.
+	Price float64
+	// ImpliedVolatility is the volatility Price and Greeks are computed
+	// at.
+	ImpliedVolatility float64
+}
+
+// Evaluate solves for the volatility implied by the mark price of md and
+// returns the option's Greeks at it. The Volatility of p is ignored.
+func Evaluate(p Params, md *robinhood.MarketData) (Result, error) {
+	mark := md.MarkPrice.Float64()
+	iv, err := p.ImpliedVolatility(mark)
+	if err != nil {
+		return Result{}, errors.Wrapf(err, "error solving implied volatility of %s", md.Instrument)
+	}
+	p.Volatility = iv
+	return Result{Greeks: p.Greeks(), Price: p.Price(), ImpliedVolatility: iv}, nil
+}
+
+// Reported returns the Greeks and implied volatility reported in md, priced
+// at its mark, for comparison with Evaluate. Values the API left null are
+// NaN.
+func Reported(md *robinhood.MarketData) (Result, error) {
+	r := Result{
+		Greeks: Greeks{Delta: reportedFloat(md.Delta), Gamma: reportedFloat(md.Gamma)},
+		Price:  md.MarkPrice.Float64(),
+	}
+	for _, f := range []struct {
+		name string
+		s    string
+		dest *float64
+	}{
+		{"implied_volatility", md.ImpliedVolatility, &r.ImpliedVolatility},
+		{"rho", md.Rho, &r.Rho},
+		{"theta", md.Theta, &r.Theta},
+		{"vega", md.Vega, &r.Vega},
+	} {
+		v, err := parseReported(f.s)
+		if err != nil {
+			return r, errors.Wrapf(err, "error parsing %s", f.name)
+		}
+		*f.dest = v
+	}
+	return r, nil
+}
+
+func reportedFloat(p *float64) float64 {
+	if p == nil {
+		return math.NaN()
+	}
+	return *p
+}
+
+func parseReported(s string) (float64, error) {
+	if s == "" || s == "null" {
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// Fill sets the implied volatility and Greeks of md that the API left null
+// from r, as computed by Evaluate.
+func Fill(md *robinhood.MarketData, r Result) {
+	for _, f := range []struct {
+		dest **float64
+		v    float64
+	}{
+		{&md.Delta, r.Delta},
+		{&md.Gamma, r.Gamma},
+	} {
+		if *f.dest == nil {
+			v := f.v
+			*f.dest = &v
+		}
+	}
+	for _, f := range []struct {
+		dest *string
+		v    float64
+	}{
+		{&md.ImpliedVolatility, r.ImpliedVolatility},
+		{&md.Rho, r.Rho},
+		{&md.Theta, r.Theta},
+		{&md.Vega, r.Vega},
+	} {
+		if *f.dest == "" || *f.dest == "null" {
+			*f.dest = strconv.FormatFloat(f.v, 'f', 6, 64)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiry(t *testing.T) {
+	ny := newYork()
+	exp := robinhood.NewDate(2021, 6, 18)
+	require.InDelta(t, 1.0/daysPerYear, Expiry(exp, time.Date(2021, 6, 17, 16, 0, 0, 0, ny)), 1e-12)
+	require.InDelta(t, 0.25/daysPerYear, Expiry(exp, time.Date(2021, 6, 18, 10, 0, 0, 0, ny)), 1e-12)
+	require.Equal(t, 0.0, Expiry(exp, time.Date(2021, 6, 18, 16, 0, 0, 0, ny)))
+	require.Equal(t, 0.0, Expiry(exp, time.Date(2021, 7, 1, 0, 0, 0, 0, ny)))
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2021, 5, 19, 16, 0, 0, 0, newYork())
+	oi := &robinhood.OptionInstrument{
+		Type:           "put",
+		StrikePrice:    robinhood.NewDecimal(95, 0),
+		ExpirationDate: robinhood.NewDate(2021, 6, 18),
+		URL:            "https://api.robinhood.com/options/instruments/x/",
+	}
+	q := robinhood.Quote{Symbol: "XYZ", LastTradePrice: robinhood.NewDecimal(100, 0), LastExtendedHoursTradePrice: robinhood.NewDecimal(100, 0)}
+
+	p, err := ParamsFor(oi, q, now, 0.01, 0)
+	require.NoError(t, err)
+	require.Equal(t, Params{Type: Put, Style: American, Spot: 100, Strike: 95, Expiry: 30.0 / daysPerYear, Rate: 0.01}, p)
+
+	// Price the put at 40% volatility, to the cent, and recover it.
+	want := p
+	want.Volatility = 0.4
+	mark := robinhood.MustParseDecimal("2.37")
+	require.InDelta(t, mark.Float64(), want.Price(), 0.005)
+	delta := -0.25
+	md := &robinhood.MarketData{Instrument: oi.URL, MarkPrice: mark, Delta: &delta, Vega: "0.1", ImpliedVolatility: "0.41"}
+	r, err := Evaluate(p, md)
+	require.NoError(t, err)
+	require.InDelta(t, 0.4, r.ImpliedVolatility, 0.001)
+	require.InDelta(t, 2.37, r.Price, 1e-8)
+	require.True(t, r.Delta < 0 && r.Delta > -0.5, "%+v", r)
+	require.True(t, r.Theta < 0 && r.Vega > 0 && r.Rho < 0, "%+v", r)
+
+	rep, err := Reported(md)
+	require.NoError(t, err)
+	require.Equal(t, 0.41, rep.ImpliedVolatility)
+	require.Equal(t, 0.1, rep.Vega)
+	require.Equal(t, -0.25, rep.Delta)
+	require.True(t, math.IsNaN(rep.Gamma) && math.IsNaN(rep.Rho) && math.IsNaN(rep.Theta))
+
+	// Only the Greeks the API left null are filled in.
+	Fill(md, r)
+	require.Equal(t, "0.41", md.ImpliedVolatility)
+	require.Equal(t, "0.1", md.Vega)
+	require.Equal(t, -0.25, *md.Delta)
+	rep, err = Reported(md)
+	require.NoError(t, err)
+	require.InDelta(t, r.Theta, rep.Theta, 1e-6)
+	require.InDelta(t, r.Rho, rep.Rho, 1e-6)
+	require.Equal(t, r.Gamma, rep.Gamma)
+
+	// A mark below the intrinsic value implies no volatility.
+	p.Strike = 120
+	_, err = Evaluate(p, md)
+	require.EqualError(t, err, "error solving implied volatility of "+oi.URL+": price 2.37 is below the put's value 20: no volatility matches price")
+
+	oi.Type = "future"
+	_, err = ParamsFor(oi, q, now, 0.01, 0)
+	require.EqualError(t, err, `unknown option type "future"`)
+}
@@ -8,14 +8,14 @@ import (
 // A Quote is a representation of the data returned by the Robinhood API for
 // current stock quotes
 type Quote struct {
-	AdjustedPreviousClose       float64 `json:"adjusted_previous_close,string"`
-	AskPrice                    float64 `json:"ask_price,string"`
+	AdjustedPreviousClose       Decimal `json:"adjusted_previous_close"`
+	AskPrice                    Decimal `json:"ask_price"`
 	AskSize                     int     `json:"ask_size"`
-	BidPrice                    float64 `json:"bid_price,string"`
+	BidPrice                    Decimal `json:"bid_price"`
 	BidSize                     int     `json:"bid_size"`
-	LastExtendedHoursTradePrice float64 `json:"last_extended_hours_trade_price,string"`
-	LastTradePrice              float64 `json:"last_trade_price,string"`
-	PreviousClose               float64 `json:"previous_close,string"`
+	LastExtendedHoursTradePrice Decimal `json:"last_extended_hours_trade_price"`
+	LastTradePrice              Decimal `json:"last_trade_price"`
+	PreviousClose               Decimal `json:"previous_close"`
 	PreviousCloseDate           string  `json:"previous_close_date"`
 	Symbol                      string  `json:"symbol"`
 	TradingHalted               bool    `json:"trading_halted"`
@@ -31,7 +31,7 @@ func (c *Client) GetQuote(ctx context.Context, stocks ...string) ([]Quote, error
 }
 
 // Price returns the proper stock price even after hours
-func (q Quote) Price() float64 {
+func (q Quote) Price() Decimal {
 	if IsRegularTradingTime() {
 		return q.LastTradePrice
 	}
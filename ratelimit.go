@@ -0,0 +1,125 @@
+package robinhood
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// A RateLimiter delays requests made by a Client. Wait blocks until a
+// request to host may be sent, or returns an error if ctx is done first.
+type RateLimiter interface {
+	Wait(ctx context.Context, host string) error
+}
+
+// HostRateLimiter is a RateLimiter keeping a separate token bucket for each
+// host, so that throttling of the crypto API does not slow down equity
+// requests and vice versa.
+type HostRateLimiter struct {
+	rate  float64
+	burst int
+
+	mu      sync.Mutex
+	limits  map[string]hostLimit
+	buckets map[string]*bucket
+}
+
+type hostLimit struct {
+	rate  float64
+	burst int
+}
+
+// NewHostRateLimiter returns a HostRateLimiter allowing rate requests per
+// second to each host, in bursts of up to burst requests. A rate of zero or
+// less does not limit requests.
+func NewHostRateLimiter(rate float64, burst int) *HostRateLimiter {
+	return &HostRateLimiter{
+		rate:    rate,
+		burst:   burst,
+		limits:  map[string]hostLimit{},
+		buckets: map[string]*bucket{},
+	}
+}
+
+// SetHostLimit overrides the rate and burst for a single host, given as the
+// host[:port] of its URL.
+func (l *HostRateLimiter) SetHostLimit(host string, rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits[host] = hostLimit{rate, burst}
+	delete(l.buckets, host)
+}
+
+// Wait implements RateLimiter.
+func (l *HostRateLimiter) Wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	b, ok := l.buckets[host]
+	if !ok {
+		lim, ok := l.limits[host]
+		if !ok {
+			lim = hostLimit{l.rate, l.burst}
+		}
+		b = newBucket(lim.rate, lim.burst)
+		l.buckets[host] = b
+	}
+	l.mu.Unlock()
+	return b.wait(ctx)
+}
+
+// A bucket is a token bucket refilled at rate tokens per second, holding at
+// most burst tokens.
+type bucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64, burst int) *bucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait takes a token from the bucket, sleeping until it is available. Tokens
+// may go negative, which queues waiters in arrival order; a waiter whose
+// context ends returns its token.
+func (b *bucket) wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return ctx.Err()
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	d := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,42 @@
+package robinhood
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv, WithRateLimit(100, 2))
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		_, err := c.GetQuote(ctx, "SPY")
+		require.NoError(t, err)
+	}
+	// Dial made two requests, which used up the burst.
+	require.True(t, time.Since(start) >= 50*time.Millisecond, time.Since(start))
+}
+
+func TestRateLimitPerHost(t *testing.T) {
+	l := NewHostRateLimiter(1, 1)
+	l.SetHostLimit("nummus.robinhood.com", 0, 0)
+	ctx := context.Background()
+	require.NoError(t, l.Wait(ctx, "api.robinhood.com"))
+	require.NoError(t, l.Wait(ctx, "nummus.robinhood.com"))
+	require.NoError(t, l.Wait(ctx, "nummus.robinhood.com"))
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, l.Wait(ctx, "api.robinhood.com"))
+
+	// The cancelled waiter returned its token.
+	b := l.buckets["api.robinhood.com"]
+	require.True(t, b.tokens > -1, b.tokens)
+}
@@ -0,0 +1,116 @@
+package robinhood
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrOrderNotFound is returned by the FindOrderByRefID methods when no order
+// has the given ref_id.
+var ErrOrderNotFound = errors.New("order not found")
+
+// RefIDSearchWindow bounds the orders searched by the FindOrderByRefID
+// methods to those updated within it.
+const RefIDSearchWindow = 7 * 24 * time.Hour
+
+// NewRefID returns a new random ref_id for an order. Set it on the order
+// options, and keep it, to be able to find the order with the
+// FindOrderByRefID methods if its outcome is unknown, e.g. after a timeout.
+// The API is not known to create only one order per ref_id, so an order
+// sent again with the same ref_id may be placed twice: find it first.
+func NewRefID() string {
+	return uuid.New().String()
+}
+
+// refID returns id, or a new ref_id if it is empty.
+func refID(id string) string {
+	if id == "" {
+		return NewRefID()
+	}
+	return id
+}
+
+// postOrder sends an order payload to url. If c.RetryOrders is set, its
+// ref_id is sent as idempotency key so that the request is retried after
+// transient failures.
+func (c *Client) postOrder(ctx context.Context, url, ref string, payload interface{}, dest interface{}) error {
+	bs, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	post, err := http.NewRequest("POST", url, bytes.NewReader(bs))
+	if err != nil {
+		return fmt.Errorf("error creating POST http.Request: %w", err)
+	}
+	post.Header.Set("Content-Type", "application/json")
+	if c.RetryOrders {
+		post.Header.Set(IdempotencyKeyHeader, ref)
+	}
+	return c.DoAndDecode(ctx, post, dest)
+}
+
+// FindOrderByRefID returns the equity order placed with the given ref_id,
+// or ErrOrderNotFound. Only orders updated within RefIDSearchWindow are
+// searched.
+func (c *Client) FindOrderByRefID(ctx context.Context, ref string) (*OrderOutput, error) {
+	var out OrderOutput
+	if err := c.findByRefID(ctx, EPOrders, ref, &out); err != nil {
+		return nil, err
+	}
+	out.client = c
+	return &out, nil
+}
+
+// FindCryptoOrderByRefID returns the crypto order placed with the given
+// ref_id, or ErrOrderNotFound. Only orders updated within
+// RefIDSearchWindow are searched.
+func (c *Client) FindCryptoOrderByRefID(ctx context.Context, ref string) (*CryptoOrderOutput, error) {
+	var out CryptoOrderOutput
+	if err := c.findByRefID(ctx, EPCryptoOrders, ref, &out); err != nil {
+		return nil, err
+	}
+	out.client = c
+	return &out, nil
+}
+
+// FindOptionsOrderByRefID returns the options order placed with the given
+// ref_id, or ErrOrderNotFound. Only orders updated within
+// RefIDSearchWindow are searched.
+func (c *Client) FindOptionsOrderByRefID(ctx context.Context, ref string) (*OptionOrder, error) {
+	var out OptionOrder
+	if err := c.findByRefID(ctx, EPOptions+"orders/", ref, &out); err != nil {
+		return nil, err
+	}
+	out.client = c
+	return &out, nil
+}
+
+// findByRefID pages through the orders listed at url updated within
+// RefIDSearchWindow, most recent first, and decodes the first one with the
+// given ref_id into dest.
+func (c *Client) findByRefID(ctx context.Context, url, ref string, dest interface{}) error {
+	q := OrderQuery{UpdatedSince: time.Now().Add(-RefIDSearchWindow)}
+	it := c.NewPageIterator(q.url(url))
+	for it.HasNext() {
+		var page []json.RawMessage
+		if err := it.Next(ctx, &page); err != nil {
+			return err
+		}
+		for _, raw := range page {
+			var o struct {
+				RefID string `json:"ref_id"`
+			}
+			if json.Unmarshal(raw, &o) == nil && o.RefID == ref {
+				return c.decode(url, raw, dest)
+			}
+		}
+	}
+	return ErrOrderNotFound
+}
@@ -0,0 +1,86 @@
+package robinhood
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderRefID(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 2
+	c := dialTest(t, srv, WithRetryPolicy(fastRetries), WithOrderRetries())
+	spy := &Instrument{URL: srv.URL() + "instruments/spy/", Symbol: "SPY"}
+	opts := OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(100, 0), RefID: NewRefID()}
+
+	first, err := c.Order(ctx, spy, opts)
+	require.NoError(t, err)
+	require.Equal(t, opts.RefID, first.RefID)
+	for i := 0; i < 3; i++ {
+		_, err := c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Market, Quantity: NewDecimal(1, 0)})
+		require.NoError(t, err)
+	}
+
+	// With order retries enabled, a retried order is not placed twice.
+	srv.AddFault(robinhoodtest.Fault{Method: "POST", Path: "/orders/", Status: http.StatusServiceUnavailable, Times: 1})
+	again, err := c.Order(ctx, spy, opts)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, again.ID)
+	for _, r := range srv.Requests() {
+		if r.Method == "POST" {
+			require.NotEmpty(t, r.Header.Get(IdempotencyKeyHeader))
+		}
+	}
+
+	found, err := c.FindOrderByRefID(ctx, opts.RefID)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, found.ID)
+	require.NoError(t, found.Update(ctx))
+
+	_, err = c.FindOrderByRefID(ctx, NewRefID())
+	require.Equal(t, ErrOrderNotFound, err)
+
+	// Orders last updated before the search window are not searched.
+	now := srv.Now
+	srv.Now = func() time.Time { return now().Add(-RefIDSearchWindow - time.Hour) }
+	old := OrderOpts{Side: Buy, Type: Market, Quantity: NewDecimal(1, 0), RefID: NewRefID()}
+	_, err = c.Order(ctx, spy, old)
+	require.NoError(t, err)
+	srv.Now = now
+	_, err = c.FindOrderByRefID(ctx, old.RefID)
+	require.Equal(t, ErrOrderNotFound, err)
+}
+
+func TestCryptoAndOptionsOrderRefID(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+	pair := CryptoCurrencyPair{ID: "btc", MinOrderPriceIncrement: MustParseDecimal("0.01"), CyrptoAssetCurrency: AssetCurrency{Increment: MustParseDecimal("0.00000001")}}
+
+	ref := NewRefID()
+	out, err := c.CryptoOrder(ctx, pair, CryptoOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(30000, 0), RefID: ref})
+	require.NoError(t, err)
+	found, err := c.FindCryptoOrderByRefID(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, out.ID, found.ID)
+	require.Equal(t, ref, found.RefID)
+
+	oi := &OptionInstrument{URL: srv.URL() + "options/instruments/x/"}
+	ref = NewRefID()
+	_, err = c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(2, 0), RefID: ref})
+	require.NoError(t, err)
+	oo, err := c.FindOptionsOrderByRefID(ctx, ref)
+	require.NoError(t, err)
+	require.Equal(t, ref, oo.RefID)
+	require.Equal(t, oi.URL, oo.Legs[0].Instrument)
+
+	_, err = c.FindOptionsOrderByRefID(ctx, NewRefID())
+	require.Equal(t, ErrOrderNotFound, err)
+}
@@ -0,0 +1,133 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ReplaceOpts holds the changes Replace makes to an order. Zero values leave
+// the corresponding setting of the order as it is.
+type ReplaceOpts struct {
+	// Price and StopPrice are the new limit and stop prices.
+	Price, StopPrice Decimal
+
+	// Quantity is the new total quantity of the order, including any
+	// shares filled before it was cancelled.
+	Quantity Decimal
+
+	// RefID is the ref_id of the new order; see OrderOpts.
+	RefID string
+
+	// PollInterval is how often the order is polled while its
+	// cancellation is pending. The default is one second.
+	PollInterval time.Duration
+}
+
+// Replace changes the price or quantity of an open order by cancelling it
+// and, once the cancellation is confirmed, placing a new order for the
+// remaining quantity with the same settings otherwise. The receiver is
+// updated along the way, so if Replace fails its State tells whether the
+// original order is still open, was cancelled, or was filled first, unless
+// the error says the order could not be refreshed.
+//
+// Orders for a dollar amount cannot be replaced.
+func (o *OrderOutput) Replace(ctx context.Context, r ReplaceOpts) (*OrderOutput, error) {
+	if o.State.IsTerminal() {
+		return nil, fmt.Errorf("order %s is %s", o.ID, o.State)
+	}
+	if o.DollarBasedAmount != nil {
+		return nil, fmt.Errorf("order %s is for a dollar amount and cannot be replaced", o.ID)
+	}
+	c := o.client
+	i, err := c.GetInstrument(ctx, o.Instrument)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting instrument")
+	}
+
+	opts := o.replacement(r)
+	if _, err := c.checkPrice(opts.Price, i.PriceTick(opts.Price), opts.Type == Limit); err != nil {
+		return nil, err
+	}
+	if opts.Quantity.Cmp(o.CumulativeQuantity) <= 0 {
+		return nil, &ValidationError{Field: "quantity", Value: opts.Quantity.String(), Reason: fmt.Sprintf("not more than the %s already filled", o.CumulativeQuantity)}
+	}
+
+	if err := o.Cancel(ctx); err != nil {
+		// The order may have been filled in the meantime.
+		if uerr := o.Update(ctx); uerr != nil {
+			return nil, errors.Wrapf(err, "error cancelling order (not refreshed: %v)", uerr)
+		}
+		return nil, errors.Wrap(err, "error cancelling order")
+	}
+	if err := o.Update(ctx); err != nil {
+		return nil, err
+	}
+	if err := o.WaitUntilDone(ctx, r.PollInterval, nil); err != nil {
+		return nil, err
+	}
+	if o.State != Cancelled {
+		return nil, fmt.Errorf("order %s was %s before it was cancelled", o.ID, o.State)
+	}
+
+	// Shares filled while the cancellation was pending are not bought or
+	// sold again.
+	opts.Quantity = opts.Quantity.Sub(o.CumulativeQuantity)
+	if opts.Quantity.Sign() <= 0 {
+		return nil, fmt.Errorf("order %s was filled before it was cancelled", o.ID)
+	}
+	out, err := c.Order(ctx, i, opts)
+	if err != nil {
+		return out, errors.Wrapf(err, "order %s was cancelled but not replaced", o.ID)
+	}
+	return out, nil
+}
+
+// replacement returns the options of an order like o, changed by r.
+func (o *OrderOutput) replacement(r ReplaceOpts) OrderOpts {
+	opts := OrderOpts{
+		Side:          o.Side,
+		Type:          o.Type,
+		Quantity:      o.Quantity,
+		Price:         o.Price,
+		StopPrice:     o.StopPrice,
+		TimeInForce:   parseTimeInForce(o.TimeInForce),
+		ExtendedHours: o.ExtendedHours,
+		Force:         o.OverrideDayTradeChecks || o.OverrideDtbpChecks,
+		RefID:         r.RefID,
+		Account:       &Account{Meta: Meta{URL: o.Account}},
+	}
+	if peg := o.TrailingPeg; peg != nil {
+		// Let Order work out a new stop price from the latest quote.
+		opts.StopPrice = Decimal{}
+		if peg.Percentage != nil {
+			opts.TrailingPercent = *peg.Percentage
+		}
+		if peg.Price != nil {
+			opts.TrailingAmount = peg.Price.Amount
+		}
+	}
+	if !r.Price.IsZero() {
+		opts.Price = r.Price
+	}
+	if !r.StopPrice.IsZero() {
+		opts.StopPrice = r.StopPrice
+	}
+	if !r.Quantity.IsZero() {
+		opts.Quantity = r.Quantity
+	}
+	return opts
+}
+
+// parseTimeInForce returns the TimeInForce named s, e.g. "gfd", or GTC.
+func parseTimeInForce(s string) TimeInForce {
+	for t := GTC; t <= FOK; t++ {
+		if strings.EqualFold(s, t.String()) {
+			return t
+		}
+	}
+	return GTC
+}
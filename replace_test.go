@@ -0,0 +1,89 @@
+package robinhood
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplace(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+	ri := srv.AddInstrument(robinhoodtest.Instrument{Symbol: "SPY"})
+	spy := &Instrument{URL: ri.URL, Symbol: "SPY"}
+
+	orig, err := c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(10, 0), Price: NewDecimal(100, 0), TimeInForce: GTC, Force: true})
+	require.NoError(t, err)
+	require.NoError(t, srv.FillOrder(orig.ID, "4", "100"))
+
+	out, err := orig.Replace(ctx, ReplaceOpts{Price: MustParseDecimal("99.5")})
+	require.NoError(t, err)
+	require.Equal(t, Cancelled, orig.State)
+	require.NotEqual(t, orig.ID, out.ID)
+	require.Equal(t, "6", out.Quantity.String())
+	require.Equal(t, "99.5", out.Price.String())
+	require.Equal(t, Buy, out.Side)
+	require.Equal(t, Limit, out.Type)
+	require.Equal(t, "gtc", out.TimeInForce)
+	require.True(t, out.OverrideDtbpChecks)
+	require.Equal(t, orig.Account, out.Account)
+
+	// Quantity is the new total, including shares already filled.
+	require.NoError(t, srv.FillOrder(out.ID, "1", "99.5"))
+	require.NoError(t, out.Update(ctx))
+	next, err := out.Replace(ctx, ReplaceOpts{Quantity: NewDecimal(3, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "2", next.Quantity.String())
+	require.Equal(t, "99.5", next.Price.String())
+}
+
+func TestReplaceErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+	ri := srv.AddInstrument(robinhoodtest.Instrument{Symbol: "SPY"})
+	spy := &Instrument{URL: ri.URL, Symbol: "SPY"}
+
+	out, err := c.Order(ctx, spy, OrderOpts{Side: Sell, Type: Limit, Quantity: NewDecimal(5, 0), Price: NewDecimal(100, 0)})
+	require.NoError(t, err)
+	require.NoError(t, srv.FillOrder(out.ID, "2", "100"))
+	require.NoError(t, out.Update(ctx))
+
+	// Invalid replacements leave the order open.
+	_, err = out.Replace(ctx, ReplaceOpts{Price: MustParseDecimal("100.001")})
+	require.EqualError(t, err, "invalid order price 100.001: not a multiple of 0.01")
+	_, err = out.Replace(ctx, ReplaceOpts{Quantity: NewDecimal(2, 0)})
+	require.EqualError(t, err, "invalid order quantity 2: not more than the 2 already filled")
+	require.Equal(t, 0, countRequests(srv, "POST", "/orders/"+out.ID+"/cancel/"))
+	require.Equal(t, PartiallyFilled, out.State)
+
+	// A failed cancellation reports whether the order was refreshed.
+	srv.AddFault(robinhoodtest.Fault{Method: "POST", Path: "/orders/" + out.ID + "/cancel/", Status: http.StatusBadRequest, Detail: "nope", Times: 2})
+	srv.AddFault(robinhoodtest.Fault{Method: "GET", Path: "/orders/" + out.ID + "/", Status: http.StatusNotFound, Detail: "gone", Times: 1})
+	_, err = out.Replace(ctx, ReplaceOpts{Price: NewDecimal(101, 0)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error cancelling order (not refreshed: ")
+	require.Contains(t, err.Error(), "gone")
+	_, err = out.Replace(ctx, ReplaceOpts{Price: NewDecimal(101, 0)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error cancelling order: ")
+	require.Equal(t, PartiallyFilled, out.State)
+
+	require.NoError(t, srv.FillOrder(out.ID, "3", "100"))
+	require.NoError(t, out.Update(ctx))
+	_, err = out.Replace(ctx, ReplaceOpts{Price: NewDecimal(101, 0)})
+	require.EqualError(t, err, "order "+out.ID+" is filled")
+
+	srv.SetQuote(robinhoodtest.Quote{Symbol: "SPY", LastTradePrice: "100.00", LastExtendedHoursTradePrice: "100.00"})
+	spy.FractionalTradability = "tradable"
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Market, Notional: NewDecimal(50, 0)})
+	require.NoError(t, err)
+	_, err = out.Replace(ctx, ReplaceOpts{Quantity: NewDecimal(1, 0)})
+	require.EqualError(t, err, "order "+out.ID+" is for a dollar amount and cannot be replaced")
+}
@@ -0,0 +1,213 @@
+package robinhood
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// IdempotencyKeyHeader marks a request as safe to repeat. Requests other
+// than GET, HEAD and OPTIONS are only ever retried if they carry it.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// A RetryPolicy decides whether, and after how long, a failed request is
+// retried. Backoff is called after each failed attempt with the number of
+// retries made so far (starting at 0) and either the response or the error
+// the attempt ended with; the response body has already been read.
+type RetryPolicy interface {
+	Backoff(retries int, res *http.Response, err error) (time.Duration, bool)
+}
+
+// ExponentialBackoff retries throttled (429) and server error (5xx)
+// responses and dropped connections, doubling the delay after each attempt.
+// A Retry-After header on the response takes precedence over the computed
+// delay, but is still capped by MaxDelay.
+type ExponentialBackoff struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// DefaultRetryPolicy is the RetryPolicy used by clients returned from Dial.
+var DefaultRetryPolicy RetryPolicy = ExponentialBackoff{
+	MaxRetries: 4,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+// Backoff implements RetryPolicy.
+func (b ExponentialBackoff) Backoff(retries int, res *http.Response, err error) (time.Duration, bool) {
+	if retries >= b.MaxRetries {
+		return 0, false
+	}
+	if err != nil {
+		return b.delay(retries), isTransient(err)
+	}
+	if res.StatusCode != http.StatusTooManyRequests && res.StatusCode < 500 {
+		return 0, false
+	}
+	if d, ok := retryAfter(res); ok {
+		if b.MaxDelay > 0 && d > b.MaxDelay {
+			d = b.MaxDelay
+		}
+		return d, true
+	}
+	return b.delay(retries), true
+}
+
+// delay returns the jittered exponential delay before the given retry.
+func (b ExponentialBackoff) delay(retries int) time.Duration {
+	d := b.BaseDelay << uint(retries)
+	if d <= 0 || (b.MaxDelay > 0 && d > b.MaxDelay) {
+		d = b.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses the Retry-After header of res, given either in seconds
+// or as an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// isTransient reports whether err is a network failure worth retrying.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+// isIdempotent reports whether req may be sent more than once.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// retryCanceledError is returned when the context of a request ends while
+// waiting to retry it. It wraps the context's error and describes the
+// failure that would have been retried.
+type retryCanceledError struct {
+	err, last error
+}
+
+func (e *retryCanceledError) Error() string {
+	return fmt.Sprintf("%v while waiting to retry: %v", e.err, e.last)
+}
+
+func (e *retryCanceledError) Unwrap() error {
+	return e.err
+}
+
+// do sends req, retrying according to c.Retry, and returns the final
+// response with its body read into memory.
+func (c *Client) do(req *http.Request) (*http.Response, []byte, error) {
+	ctx := req.Context()
+	for retries := 0; ; retries++ {
+		res, body, err := c.roundTrip(req)
+		if c.Retry == nil || !isIdempotent(req) || (req.Body != nil && req.GetBody == nil) {
+			return res, body, err
+		}
+		wait, ok := c.Retry.Backoff(retries, res, err)
+		if !ok {
+			return res, body, err
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			last := err
+			if last == nil {
+				last = newAPIError(req, res, body)
+			}
+			return nil, nil, &retryCanceledError{err: ctx.Err(), last: last}
+		case <-t.C:
+		}
+
+		if req.GetBody != nil {
+			b, err := req.GetBody()
+			if err != nil {
+				return nil, nil, err
+			}
+			req.Body = b
+		}
+	}
+}
+
+func (c *Client) roundTrip(req *http.Request) (*http.Response, []byte, error) {
+	if c.Limiter != nil {
+		if err := c.Limiter.Wait(req.Context(), req.URL.Host); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	h := c.hook()
+	var info *ResponseInfo
+	if h != nil {
+		info = &ResponseInfo{Request: requestInfo(req)}
+		h.BeforeRequest(req.Context(), &info.Request)
+	}
+
+	start := time.Now()
+	res, data, err := c.send(req)
+	if h != nil {
+		info.Latency = time.Since(start)
+		info.Err = err
+		if res != nil {
+			info.StatusCode = res.StatusCode
+			info.Body = RedactBody(data)
+		}
+		h.AfterResponse(req.Context(), info)
+	}
+	return res, data, err
+}
+
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	res, err := c.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return res, data, nil
+}
@@ -0,0 +1,96 @@
+package robinhood
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+var fastRetries = ExponentialBackoff{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestRetryGet(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv, WithRetryPolicy(fastRetries))
+
+	srv.AddFault(robinhoodtest.Fault{Path: "/quotes/", Status: http.StatusTooManyRequests, RetryAfter: "0", Times: 2})
+	_, err := c.GetQuote(ctx, "SPY")
+	require.NoError(t, err)
+	require.Equal(t, 3, countRequests(srv, "GET", "/quotes/"))
+
+	srv.AddFault(robinhoodtest.Fault{Path: "/quotes/", Drop: true, Times: 1})
+	_, err = c.GetQuote(ctx, "SPY")
+	require.NoError(t, err)
+
+	srv.AddFault(robinhoodtest.Fault{Path: "/quotes/", Status: http.StatusBadGateway})
+	_, err = c.GetQuote(ctx, "SPY")
+	require.True(t, IsRetryable(err))
+	require.Equal(t, 3+2+4, countRequests(srv, "GET", "/quotes/"))
+}
+
+func TestRetryPost(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv, WithRetryPolicy(fastRetries))
+
+	// Orders are not retried unless RetryOrders is set.
+	srv.AddFault(robinhoodtest.Fault{Method: "POST", Path: "/orders/", Status: http.StatusServiceUnavailable, Times: 1})
+	_, err := c.Order(ctx, &Instrument{URL: srv.URL() + "instruments/x/"}, OrderOpts{Side: Buy, Type: Market, Quantity: NewDecimal(1, 0)})
+	require.Error(t, err)
+	require.Equal(t, 1, countRequests(srv, "POST", "/orders/"))
+	require.Empty(t, srv.Requests()[len(srv.Requests())-1].Header.Get(IdempotencyKeyHeader))
+
+	srv.AddFault(robinhoodtest.Fault{Method: "POST", Path: "/orders/", Status: http.StatusServiceUnavailable, Times: 1})
+	req, err := http.NewRequest("POST", EPOrders, bytes.NewReader([]byte(`{"quantity": 1}`)))
+	require.NoError(t, err)
+	require.Error(t, c.DoAndDecode(ctx, req, nil))
+	require.Equal(t, 2, countRequests(srv, "POST", "/orders/"))
+
+	srv.AddFault(robinhoodtest.Fault{Method: "POST", Path: "/orders/", Status: http.StatusServiceUnavailable, Times: 1})
+	req, err = http.NewRequest("POST", EPOrders, bytes.NewReader([]byte(`{"quantity": 1}`)))
+	require.NoError(t, err)
+	req.Header.Set(IdempotencyKeyHeader, "key")
+	var out OrderOutput
+	require.NoError(t, c.DoAndDecode(ctx, req, &out))
+	require.Equal(t, "1", out.Quantity.String())
+	require.Equal(t, 4, countRequests(srv, "POST", "/orders/"))
+}
+
+func TestRetryContext(t *testing.T) {
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv, WithRetryPolicy(ExponentialBackoff{MaxRetries: 3, BaseDelay: time.Hour}))
+
+	srv.AddFault(robinhoodtest.Fault{Path: "/quotes/", Status: http.StatusInternalServerError})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.GetQuote(ctx, "SPY")
+	require.True(t, errors.Is(err, context.DeadlineExceeded), err)
+	require.Contains(t, err.Error(), "while waiting to retry: ")
+	require.Contains(t, err.Error(), "500")
+	require.Equal(t, 1, countRequests(srv, "GET", "/quotes/"))
+}
+
+func TestRetryAfterDate(t *testing.T) {
+	res := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	res.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	d, ok := ExponentialBackoff{MaxRetries: 1}.Backoff(0, res, nil)
+	require.True(t, ok)
+	require.True(t, d > 50*time.Second, d)
+	d, ok = fastRetries.Backoff(0, res, nil)
+	require.True(t, ok)
+	require.Equal(t, fastRetries.MaxDelay, d)
+
+	_, ok = fastRetries.Backoff(0, &http.Response{StatusCode: http.StatusBadRequest}, nil)
+	require.False(t, ok)
+	_, ok = fastRetries.Backoff(3, res, nil)
+	require.False(t, ok)
+}
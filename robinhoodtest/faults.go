@@ -0,0 +1,78 @@
+package robinhoodtest
+
+import (
+	"net/http"
+	"strings"
+)
+
+// A Fault makes the Server fail requests instead of serving them.
+type Fault struct {
+	// Method and Path select the requests to fail. An empty Method matches
+	// any method, and Path is matched as a prefix of the request path.
+	Method, Path string
+
+	// Times is the number of matching requests to fail before the fault
+	// clears. Zero fails every matching request.
+	Times int
+
+	// Status and Detail make up the error response. RetryAfter, if set, is
+	// sent as the Retry-After header.
+	Status     int
+	Detail     string
+	RetryAfter string
+
+	// Drop closes the connection without writing any response.
+	Drop bool
+}
+
+// AddFault installs a fault. Faults are consulted in the order they were
+// added, and only the first matching one applies.
+func (s *Server) AddFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// fault serves r according to the first matching fault, if any, and reports
+// whether it did.
+func (s *Server) fault(w http.ResponseWriter, r *http.Request) bool {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method || !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			if f.Times--; f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+
+		if f.Drop {
+			if hj, ok := w.(http.Hijacker); ok {
+				if conn, _, err := hj.Hijack(); err == nil {
+					conn.Close()
+					return true
+				}
+			}
+		}
+		if f.RetryAfter != "" {
+			w.Header().Set("Retry-After", f.RetryAfter)
+		}
+		status, detail := f.Status, f.Detail
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		if detail == "" {
+			detail = http.StatusText(status)
+		}
+		writeError(w, status, detail)
+		return true
+	}
+	return false
+}
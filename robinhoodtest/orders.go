@@ -0,0 +1,318 @@
+package robinhoodtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+// terminalStates are the order states from which an order never moves.
+var terminalStates = []string{"filled", "cancelled", "rejected", "failed"}
+
+// An orderStore holds orders of one kind, keyed by ID, as the JSON objects
+// the API returns.
+type orderStore struct {
+	path   string
+	orders []map[string]interface{}
+}
+
+func newOrderStore(path string) *orderStore {
+	return &orderStore{path: path}
+}
+
+func (o *orderStore) get(id string) map[string]interface{} {
+	for _, ord := range o.orders {
+		if ord["id"] == id {
+			return ord
+		}
+	}
+	return nil
+}
+
+// Order returns a copy of the equity, option or crypto order with the given ID as
+// its JSON object.
+func (s *Server) Order(id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ord := s.findOrder(id)
+	if ord == nil {
+		return nil, false
+	}
+	cp := make(map[string]interface{}, len(ord))
+	for k, v := range ord {
+		cp[k] = v
+	}
+	return cp, true
+}
+
+// SetOrderState moves the equity, option or crypto order with the given ID to
+// state.
+func (s *Server) SetOrderState(id, state string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ord := s.findOrder(id)
+	if ord == nil {
+		return fmt.Errorf("robinhoodtest: no order %q", id)
+	}
+	s.setState(ord, state)
+	return nil
+}
+
+// RejectOrder moves the order with the given ID to "rejected" with reason.
+func (s *Server) RejectOrder(id, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ord := s.findOrder(id)
+	if ord == nil {
+		return fmt.Errorf("robinhoodtest: no order %q", id)
+	}
+	ord["reject_reason"] = reason
+	s.setState(ord, "rejected")
+	return nil
+}
+
+// FillOrder records an execution of quantity at price against the order
+// with the given ID, moving it to "partially_filled" or "filled".
+func (s *Server) FillOrder(id, quantity, price string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ord := s.findOrder(id)
+	if ord == nil {
+		return fmt.Errorf("robinhoodtest: no order %q", id)
+	}
+	return s.fill(ord, quantity, price)
+}
+
+func (s *Server) findOrder(id string) map[string]interface{} {
+	for _, store := range []*orderStore{s.orders, s.cryptoOrders, s.optionOrders} {
+		if ord := store.get(id); ord != nil {
+			return ord
+		}
+	}
+	return nil
+}
+
+func (s *Server) setState(ord map[string]interface{}, state string) {
+	ord["state"] = state
+	ord["updated_at"] = s.timestamp()
+	ord["last_transaction_at"] = ord["updated_at"]
+}
+
+func (s *Server) fill(ord map[string]interface{}, quantity, price string) error {
+	qty, ok := new(big.Rat).SetString(quantity)
+	if !ok {
+		return fmt.Errorf("robinhoodtest: bad quantity %q", quantity)
+	}
+	px, ok := new(big.Rat).SetString(price)
+	if !ok {
+		return fmt.Errorf("robinhoodtest: bad price %q", price)
+	}
+
+	cum := ratOf(ord["cumulative_quantity"])
+	avg := ratOf(ord["average_price"])
+	notional := new(big.Rat).Mul(cum, avg)
+	notional.Add(notional, new(big.Rat).Mul(qty, px))
+	cum.Add(cum, qty)
+	avg.Quo(notional, cum)
+
+	ord["cumulative_quantity"] = cum.FloatString(5)
+	ord["average_price"] = avg.FloatString(8)
+	ord["executions"] = append(ord["executions"].([]interface{}), map[string]interface{}{
+		"id":              s.newID(),
+		"price":           px.FloatString(8),
+		"quantity":        qty.FloatString(5),
+		"settlement_date": s.Now().AddDate(0, 0, 2).Format("2006-01-02"),
+		"timestamp":       s.timestamp(),
+	})
+
+	state := "partially_filled"
+	if total := ratOf(ord["quantity"]); total.Sign() == 0 || cum.Cmp(total) >= 0 {
+		state = "filled"
+	}
+	s.setState(ord, state)
+	return nil
+}
+
+// advance moves ord one step along s.OrderLifecycle.
+func (s *Server) advance(ord map[string]interface{}) {
+	for i, st := range s.OrderLifecycle {
+		if st != ord["state"] || i+1 == len(s.OrderLifecycle) {
+			continue
+		}
+		next := s.OrderLifecycle[i+1]
+		if next == "filled" {
+			remaining := new(big.Rat).Sub(ratOf(ord["quantity"]), ratOf(ord["cumulative_quantity"]))
+			price, _ := ord["price"].(string)
+			if remaining.Sign() > 0 && price != "" {
+				s.fill(ord, remaining.FloatString(8), price)
+				return
+			}
+		}
+		s.setState(ord, next)
+		return
+	}
+}
+
+func ratOf(v interface{}) *big.Rat {
+	r := new(big.Rat)
+	if s, ok := v.(string); ok {
+		r.SetString(s)
+	}
+	return r
+}
+
+// newOrder builds the stored form of an order from a POSTed payload. Numbers
+// in the payload are kept as the decimal strings the API responds with.
+func (s *Server) newOrder(store *orderStore, r *http.Request) (map[string]interface{}, error) {
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	var payload map[string]interface{}
+	if err := dec.Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	// Like the real API, a repeated ref_id returns the order it created
+	// instead of placing another one.
+	if ref, _ := payload["ref_id"].(string); ref != "" {
+		for _, ord := range store.orders {
+			if ord["ref_id"] == ref {
+				return ord, nil
+			}
+		}
+	}
+
+	ord := map[string]interface{}{}
+	for k, v := range payload {
+		if n, ok := v.(json.Number); ok {
+			v = n.String()
+		}
+		ord[k] = v
+	}
+
+	id := s.newID()
+	state := "queued"
+	if len(s.OrderLifecycle) > 0 {
+		state = s.OrderLifecycle[0]
+	}
+	ord["id"] = id
+	ord["url"] = s.url("%s%s/", store.path, id)
+	ord["cancel"] = s.url("%s%s/cancel/", store.path, id)
+	ord["state"] = state
+	ord["cumulative_quantity"] = "0.00000"
+	ord["average_price"] = nil
+	ord["executions"] = []interface{}{}
+	ord["fees"] = "0.00"
+	ord["reject_reason"] = nil
+	ord["created_at"] = s.timestamp()
+	ord["updated_at"] = ord["created_at"]
+	ord["last_transaction_at"] = ord["created_at"]
+
+	store.orders = append(store.orders, ord)
+	return ord, nil
+}
+
+func (s *Server) createOrder(w http.ResponseWriter, r *http.Request, _ []string) {
+	ord, err := s.newOrder(s.orders, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, ord)
+}
+
+func (s *Server) createCryptoOrder(w http.ResponseWriter, r *http.Request, _ []string) {
+	ord, err := s.newOrder(s.cryptoOrders, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ord["account"] = ord["account_id"]
+	writeJSON(w, http.StatusCreated, ord)
+}
+
+func (s *Server) createOptionOrder(w http.ResponseWriter, r *http.Request, _ []string) {
+	ord, err := s.newOrder(s.optionOrders, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, ok := ord["cancel_url"]; !ok {
+		ord["cancel_url"] = ord["cancel"]
+		ord["processed_quantity"] = "0.00000"
+		ord["canceled_quantity"] = "0.00000"
+		ord["pending_quantity"] = ord["quantity"]
+		legs, _ := ord["legs"].([]interface{})
+		for _, l := range legs {
+			if leg, ok := l.(map[string]interface{}); ok {
+				leg["id"] = s.newID()
+				leg["executions"] = []interface{}{}
+			}
+		}
+	}
+	writeJSON(w, http.StatusCreated, ord)
+}
+
+// listOrders lists the orders of a store, most recent first, filtered by the
+// updated_at[gte], instrument, state and symbol query parameters.
+func (s *Server) listOrders(store *orderStore) func(http.ResponseWriter, *http.Request, []string) {
+	return func(w http.ResponseWriter, r *http.Request, _ []string) {
+		q := r.URL.Query()
+		var since time.Time
+		if v := q.Get("updated_at[gte]"); v != "" {
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "Enter a valid date/time.")
+				return
+			}
+			since = t
+		}
+		items := make([]interface{}, 0, len(store.orders))
+		for i := len(store.orders) - 1; i >= 0; i-- {
+			ord := store.orders[i]
+			updated, _ := time.Parse(time.RFC3339Nano, fmt.Sprint(ord["updated_at"]))
+			switch {
+			case updated.Before(since),
+				q.Get("instrument") != "" && ord["instrument"] != q.Get("instrument"),
+				q.Get("state") != "" && ord["state"] != q.Get("state"),
+				q.Get("symbol") != "" && ord["symbol"] != q.Get("symbol") && ord["chain_symbol"] != q.Get("symbol"):
+				continue
+			}
+			items = append(items, ord)
+		}
+		s.writePage(w, r, items)
+	}
+}
+
+func (s *Server) getOrder(store *orderStore) func(http.ResponseWriter, *http.Request, []string) {
+	return func(w http.ResponseWriter, r *http.Request, args []string) {
+		ord := store.get(args[0])
+		if ord == nil {
+			writeError(w, http.StatusNotFound, "Not found.")
+			return
+		}
+		s.advance(ord)
+		writeJSON(w, http.StatusOK, ord)
+	}
+}
+
+func (s *Server) cancelOrder(store *orderStore) func(http.ResponseWriter, *http.Request, []string) {
+	return func(w http.ResponseWriter, r *http.Request, args []string) {
+		ord := store.get(args[0])
+		if ord == nil {
+			writeError(w, http.StatusNotFound, "Not found.")
+			return
+		}
+		if state, _ := ord["state"].(string); contains(terminalStates, state) {
+			writeError(w, http.StatusBadRequest, "Order cannot be cancelled.")
+			return
+		}
+		s.setState(ord, "cancelled")
+		ord["cancel"] = nil
+		if _, ok := ord["cancel_url"]; ok {
+			ord["cancel_url"] = nil
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}
+}
@@ -0,0 +1,119 @@
+package robinhoodtest
+
+import (
+	"math/big"
+	"net/http"
+)
+
+// AddPosition seeds an equity position in the account with the given number
+// and returns it with its URLs filled in.
+func (s *Server) AddPosition(accountNumber string, p Position) Position {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p.Account = s.url("accounts/%s/", accountNumber)
+	p.URL = s.url("positions/%s/%s/", accountNumber, lastSegment(p.Instrument))
+	if p.CreatedAt == "" {
+		p.CreatedAt = s.timestamp()
+	}
+	if p.UpdatedAt == "" {
+		p.UpdatedAt = p.CreatedAt
+	}
+	s.positions = append(s.positions, p)
+	return p
+}
+
+// AddOptionPosition seeds an options position in the account with the given
+// number and returns it with its IDs filled in.
+func (s *Server) AddOptionPosition(accountNumber string, p OptionPosition) OptionPosition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p.Account = s.url("accounts/%s/", accountNumber)
+	if p.ID == "" {
+		p.ID = s.newID()
+	}
+	for i := range p.Legs {
+		if p.Legs[i].ID == "" {
+			p.Legs[i].ID = s.newID()
+		}
+		if p.Legs[i].RatioQuantity == 0 {
+			p.Legs[i].RatioQuantity = 1
+		}
+		if p.Legs[i].ExpirationDate == "" {
+			p.Legs[i].ExpirationDate = s.Now().UTC().Format("2006-01-02")
+		}
+	}
+	if p.CreatedAt == "" {
+		p.CreatedAt = s.timestamp()
+	}
+	if p.UpdatedAt == "" {
+		p.UpdatedAt = p.CreatedAt
+	}
+	s.optionPositions = append(s.optionPositions, p)
+	return p
+}
+
+// SetPortfolio seeds or replaces the portfolio of the account with the given
+// number.
+func (s *Server) SetPortfolio(accountNumber string, p Portfolio) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p.Account = s.url("accounts/%s/", accountNumber)
+	p.URL = s.url("accounts/%s/portfolio/", accountNumber)
+	s.portfolios[accountNumber] = p
+}
+
+func (s *Server) listPositions(w http.ResponseWriter, r *http.Request, args []string) {
+	account := ""
+	if len(args) > 0 {
+		account = s.url("accounts/%s/", args[0])
+	}
+	nonZero := r.URL.Query().Get("nonzero") == "True"
+
+	var items []interface{}
+	for _, p := range s.positions {
+		if account != "" && p.Account != account {
+			continue
+		}
+		if nonZero && ratOf(p.Quantity).Cmp(new(big.Rat)) == 0 {
+			continue
+		}
+		items = append(items, p)
+	}
+	s.writePage(w, r, items)
+}
+
+func (s *Server) listOptionPositions(w http.ResponseWriter, r *http.Request, _ []string) {
+	numbers := splitList(r.URL.Query().Get("account_numbers"))
+	nonZero := r.URL.Query().Get("nonzero") == "True"
+
+	var items []interface{}
+	for _, p := range s.optionPositions {
+		if len(numbers) > 0 && !contains(numbers, lastSegment(p.Account)) {
+			continue
+		}
+		if nonZero && ratOf(p.Quantity).Sign() == 0 {
+			continue
+		}
+		items = append(items, p)
+	}
+	s.writePage(w, r, items)
+}
+
+func (s *Server) listPortfolios(w http.ResponseWriter, r *http.Request, _ []string) {
+	var items []interface{}
+	for _, a := range s.accounts {
+		if p, ok := s.portfolios[a.AccountNumber]; ok {
+			items = append(items, p)
+		}
+	}
+	s.writePage(w, r, items)
+}
+
+func (s *Server) getPortfolio(w http.ResponseWriter, r *http.Request, args []string) {
+	p, ok := s.portfolios[args[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
@@ -0,0 +1,330 @@
+package robinhoodtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nikunjy/robinhood/internal/redact"
+)
+
+// RecorderMode selects whether a Recorder talks to the network.
+type RecorderMode int
+
+// Recorder modes.
+const (
+	// ModeReplay serves responses from the cassette only.
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends requests to the real transport and records them.
+	ModeRecord
+	// ModeAuto replays if the cassette file exists and records otherwise.
+	ModeAuto
+)
+
+// A Cassette is the recorded form of a series of HTTP interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// An Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded form of a request. Headers are not kept,
+// as they carry the access token.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded form of a response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// keptHeaders are the response headers recorded in a cassette.
+var keptHeaders = []string{"Content-Type", "Retry-After"}
+
+// Recorder is an http.RoundTripper that records interactions with the real
+// API into a cassette file and replays them later, for use with
+// robinhood.WithTransport. When saved, tokens and passwords are removed from
+// the cassette and account numbers are replaced by placeholders throughout.
+//
+// Replayed requests are matched on method, URL and body, ignoring
+// credentials and ref_id fields, and each recorded interaction is replayed
+// once, in order.
+type Recorder struct {
+	// Transport sends requests in ModeRecord. If nil,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// Replace maps additional strings, such as user IDs, to the
+	// placeholders they are saved as.
+	Replace map[string]string
+
+	path   string
+	replay bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder for the cassette file at path, loading it
+// unless recording.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{path: path}
+	if mode == ModeAuto {
+		mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			mode = ModeReplay
+		}
+	}
+	if mode != ModeReplay {
+		return r, nil
+	}
+
+	r.replay = true
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &r.cassette); err != nil {
+		return nil, fmt.Errorf("robinhoodtest: decoding cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Replaying reports whether the recorder serves responses from its cassette.
+func (r *Recorder) Replaying() bool {
+	return r.replay
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.replay {
+		return r.replayed(req, string(body))
+	}
+
+	t := r.Transport
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	res, err := t.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	header := http.Header{}
+	for _, k := range keptHeaders {
+		if v := res.Header.Get(k); v != "" {
+			header.Set(k, v)
+		}
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     header,
+			Body:       string(resBody),
+		},
+	})
+	r.mu.Unlock()
+	return res, nil
+}
+
+func (r *Recorder) replayed(req *http.Request, body string) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u := req.URL.String()
+	b := matchBody(body)
+	for i, it := range r.cassette.Interactions {
+		if r.used[i] || it.Request.Method != req.Method || it.Request.URL != u || matchBody(it.Request.Body) != b {
+			continue
+		}
+		r.used[i] = true
+		header := it.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", it.Response.StatusCode, http.StatusText(it.Response.StatusCode)),
+			StatusCode:    it.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(it.Response.Body)),
+			ContentLength: int64(len(it.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("robinhoodtest: no recorded interaction for %s %s", req.Method, u)
+}
+
+// Cassette returns the interactions recorded or loaded so far, scrubbed as
+// they would be saved.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.replay {
+		return r.cassette
+	}
+	return r.scrubbed()
+}
+
+// Save writes the scrubbed cassette to its file. It does nothing when
+// replaying.
+func (r *Recorder) Save() error {
+	if r.replay {
+		return nil
+	}
+	r.mu.Lock()
+	c := r.scrubbed()
+	r.mu.Unlock()
+
+	bs, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0750); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(bs, '\n'), 0640)
+}
+
+// scrubbed returns a copy of the cassette with credentials removed and
+// account numbers replaced. r.mu must be held.
+func (r *Recorder) scrubbed() Cassette {
+	replace := map[string]string{}
+	for k, v := range r.Replace {
+		replace[k] = v
+	}
+	var numbers []string
+	for _, it := range r.cassette.Interactions {
+		collectAccountNumbers([]byte(it.Response.Body), &numbers)
+	}
+	for i, n := range numbers {
+		if _, ok := replace[n]; !ok {
+			replace[n] = fmt.Sprintf("ACCOUNT%d", i+1)
+		}
+	}
+
+	// Replace longer strings first so that no placeholder is spliced into
+	// another value.
+	olds := make([]string, 0, len(replace))
+	for k := range replace {
+		olds = append(olds, k)
+	}
+	sort.Slice(olds, func(i, j int) bool { return len(olds[i]) > len(olds[j]) })
+	pairs := make([]string, 0, 2*len(olds))
+	for _, k := range olds {
+		pairs = append(pairs, k, replace[k])
+	}
+	rep := strings.NewReplacer(pairs...)
+
+	out := Cassette{Interactions: make([]Interaction, len(r.cassette.Interactions))}
+	for i, it := range r.cassette.Interactions {
+		it.Request.URL = rep.Replace(it.Request.URL)
+		it.Request.Body = rep.Replace(string(redact.Body([]byte(it.Request.Body))))
+		it.Response.Body = rep.Replace(string(redact.Body([]byte(it.Response.Body))))
+		out.Interactions[i] = it
+	}
+	return out
+}
+
+// matchBody returns the form of a request body compared when replaying:
+// credentials are redacted, and JSON bodies are re-encoded without ref_id
+// fields, which are generated afresh for each run.
+func matchBody(body string) string {
+	b := redact.Body([]byte(body))
+	var v interface{}
+	if json.Unmarshal(b, &v) != nil {
+		return string(b)
+	}
+	dropRefIDs(v)
+	out, err := json.Marshal(v)
+	if err != nil {
+		return string(b)
+	}
+	return string(out)
+}
+
+func dropRefIDs(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		delete(v, "ref_id")
+		for _, x := range v {
+			dropRefIDs(x)
+		}
+	case []interface{}:
+		for _, x := range v {
+			dropRefIDs(x)
+		}
+	}
+}
+
+// collectAccountNumbers appends every account_number value in a JSON body
+// to numbers, in order of appearance.
+func collectAccountNumbers(body []byte, numbers *[]string) {
+	var v interface{}
+	if json.Unmarshal(body, &v) != nil {
+		return
+	}
+	var walk func(interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if n, ok := v[k].(string); ok && k == "account_number" && n != "" && !contains(*numbers, n) {
+					*numbers = append(*numbers, n)
+				}
+				walk(v[k])
+			}
+		case []interface{}:
+			for _, x := range v {
+				walk(x)
+			}
+		}
+	}
+	walk(v)
+}
@@ -0,0 +1,138 @@
+package robinhoodtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikunjy/robinhood"
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "robinhoodtest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "testdata", "orders.json")
+
+	srv := robinhoodtest.NewServer()
+	srv.AddInstrument(robinhoodtest.Instrument{Symbol: "SPY"})
+	base, cryptoBase := srv.URL(), srv.CryptoURL()
+
+	session := func(rec *robinhoodtest.Recorder) (*robinhood.Client, *robinhood.OrderOutput) {
+		ctx := context.Background()
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: robinhoodtest.DefaultToken})
+		c, err := robinhood.Dial(ctx, ts,
+			robinhood.WithBaseURLs(base, cryptoBase),
+			robinhood.WithTransport(rec))
+		require.NoError(t, err)
+		i, err := c.GetInstrumentForSymbol(ctx, "SPY")
+		require.NoError(t, err)
+		out, err := c.Order(ctx, i, robinhood.OrderOpts{
+			Side:     robinhood.Buy,
+			Type:     robinhood.Limit,
+			Quantity: robinhood.NewDecimal(1, 0),
+			Price:    robinhood.NewDecimal(400, 0),
+		})
+		require.NoError(t, err)
+		return c, out
+	}
+
+	rec, err := robinhoodtest.NewRecorder(path, robinhoodtest.ModeAuto)
+	require.NoError(t, err)
+	require.False(t, rec.Replaying())
+	c, recorded := session(rec)
+	require.Equal(t, robinhoodtest.DefaultAccountNumber, c.Account.AccountNumber)
+	require.NoError(t, rec.Save())
+	srv.Close()
+
+	bs, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(bs), robinhoodtest.DefaultAccountNumber)
+	require.NotContains(t, string(bs), robinhoodtest.DefaultToken)
+	require.Contains(t, string(bs), "ACCOUNT1")
+	var cas robinhoodtest.Cassette
+	require.NoError(t, json.Unmarshal(bs, &cas))
+	require.Equal(t, rec.Cassette(), cas)
+
+	rec, err = robinhoodtest.NewRecorder(path, robinhoodtest.ModeAuto)
+	require.NoError(t, err)
+	require.True(t, rec.Replaying())
+	c, replayed := session(rec)
+	require.Equal(t, "ACCOUNT1", c.Account.AccountNumber)
+	require.Equal(t, recorded.ID, replayed.ID)
+	require.Equal(t, recorded.State, replayed.State)
+
+	_, err = c.GetInstrumentForSymbol(context.Background(), "SPY")
+	require.Error(t, err, "each interaction is replayed once")
+}
+
+func TestRecorderMatchesBody(t *testing.T) {
+	dir, err := ioutil.TempDir("", "robinhoodtest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "orders.json")
+
+	srv := robinhoodtest.NewServer()
+	srv.AddInstrument(robinhoodtest.Instrument{Symbol: "SPY"})
+	base, cryptoBase := srv.URL(), srv.CryptoURL()
+
+	session := func(rec *robinhoodtest.Recorder, prices ...int64) map[int64]string {
+		ctx := context.Background()
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: robinhoodtest.DefaultToken})
+		c, err := robinhood.Dial(ctx, ts,
+			robinhood.WithBaseURLs(base, cryptoBase),
+			robinhood.WithTransport(rec))
+		require.NoError(t, err)
+		i, err := c.GetInstrumentForSymbol(ctx, "SPY")
+		require.NoError(t, err)
+		ids := map[int64]string{}
+		for _, p := range prices {
+			out, err := c.Order(ctx, i, robinhood.OrderOpts{
+				Side:     robinhood.Buy,
+				Type:     robinhood.Limit,
+				Quantity: robinhood.NewDecimal(1, 0),
+				Price:    robinhood.NewDecimal(p, 0),
+			})
+			require.NoError(t, err)
+			ids[p] = out.ID
+		}
+		return ids
+	}
+
+	rec, err := robinhoodtest.NewRecorder(path, robinhoodtest.ModeRecord)
+	require.NoError(t, err)
+	recorded := session(rec, 400, 401)
+	require.NoError(t, rec.Save())
+	srv.Close()
+	require.NotEqual(t, recorded[400], recorded[401])
+
+	rec, err = robinhoodtest.NewRecorder(path, robinhoodtest.ModeReplay)
+	require.NoError(t, err)
+	require.Equal(t, recorded, session(rec, 401, 400))
+}
+
+func TestRecorderScrubsLogin(t *testing.T) {
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.Username, srv.Password = "user", "hunter2"
+
+	rec, err := robinhoodtest.NewRecorder(filepath.Join(os.TempDir(), "unused.json"), robinhoodtest.ModeRecord)
+	require.NoError(t, err)
+	o := &robinhood.OAuth{Username: "user", Password: "hunter2", BaseURL: srv.URL(), HTTPClient: &http.Client{Transport: rec}}
+	tok, err := o.Token()
+	require.NoError(t, err)
+
+	cas := rec.Cassette()
+	require.Len(t, cas.Interactions, 1)
+	it := cas.Interactions[0]
+	require.NotContains(t, it.Request.Body, "hunter2")
+	require.Contains(t, it.Request.Body, "username=user")
+	require.NotContains(t, it.Response.Body, tok.AccessToken)
+}
@@ -0,0 +1,720 @@
+// Package robinhoodtest provides an in-process fake of the Robinhood API for
+// hermetic tests.
+//
+// A Server speaks enough of the api.robinhood.com and nummus.robinhood.com
+// protocols for a robinhood.Client to log in, read accounts, positions,
+// portfolios, instruments, quotes and option chains, and place, list and
+// cancel equity, option and crypto orders. The crypto API is served under the
+// "/nummus/" path of the same listener:
+//
+//	srv := robinhoodtest.NewServer()
+//	defer srv.Close()
+//
+//	c, err := robinhood.Dial(ctx, srv.TokenSource(),
+//		robinhood.WithBaseURLs(srv.URL(), srv.CryptoURL()))
+//
+// The package deliberately does not import robinhood, so tests inside that
+// package may use it as well.
+package robinhoodtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultToken is the access token accepted by every Server without logging
+// in. It is the token returned by Server.TokenSource.
+const DefaultToken = "robinhoodtest-token"
+
+// DefaultAccountNumber is the number of the account every Server is seeded
+// with.
+const DefaultAccountNumber = "5RY00001"
+
+// DefaultPageSize is the number of results returned per page by list
+// endpoints unless Server.PageSize is set.
+const DefaultPageSize = 100
+
+// A Request is a request received by the Server.
+type Request struct {
+	Method   string
+	Path     string
+	RawQuery string
+	Header   http.Header
+	Body     []byte
+}
+
+// Server is a fake Robinhood API backed by an httptest.Server. All exported
+// methods are safe for concurrent use with requests being served.
+type Server struct {
+	// Username and Password are the credentials accepted by /oauth2/token/.
+	// If MFA is set, logins must also supply it as mfa_code.
+	Username, Password, MFA string
+
+	// PageSize limits the number of results per page of list endpoints.
+	PageSize int
+
+	// OrderLifecycle, if set, is the sequence of states an equity, option or
+	// crypto order advances through, one step each time the order itself is
+	// fetched. New orders start in its first state. An order advancing into
+	// "filled" is filled at its limit price.
+	OrderLifecycle []string
+
+	// Now returns the time used for created_at and updated_at fields.
+	Now func() time.Time
+
+	srv *httptest.Server
+
+	mu              sync.Mutex
+	faults          []*Fault
+	ids             int
+	tokens          map[string]bool
+	requests        []Request
+	accounts        []Account
+	cryptoAccounts  []CryptoAccount
+	instruments     []Instrument
+	quotes          map[string]Quote
+	chains          []OptionChain
+	optionInsts     []OptionInstrument
+	marketData      map[string]OptionMarketData
+	currencyPairs   []CurrencyPair
+	positions       []Position
+	optionPositions []OptionPosition
+	portfolios      map[string]Portfolio
+	orders          *orderStore
+	cryptoOrders    *orderStore
+	optionOrders    *orderStore
+}
+
+// NewServer starts and returns a new Server seeded with one brokerage
+// account (DefaultAccountNumber) and one crypto account. The caller should
+// call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		Now:        time.Now,
+		tokens:     map[string]bool{DefaultToken: true},
+		quotes:     map[string]Quote{},
+		marketData: map[string]OptionMarketData{},
+		portfolios: map[string]Portfolio{},
+	}
+	s.orders = newOrderStore("orders/")
+	s.cryptoOrders = newOrderStore("nummus/orders/")
+	s.optionOrders = newOrderStore("options/orders/")
+	s.srv = httptest.NewServer(s)
+
+	s.AddAccount(Account{AccountNumber: DefaultAccountNumber})
+	s.AddCryptoAccount(CryptoAccount{})
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// URL returns the base URL of the fake API, to be used in place of
+// robinhood.EPBase.
+func (s *Server) URL() string {
+	return s.srv.URL + "/"
+}
+
+// CryptoURL returns the base URL of the fake crypto API, to be used in place
+// of robinhood.EPCryptoBase.
+func (s *Server) CryptoURL() string {
+	return s.srv.URL + "/nummus/"
+}
+
+// TokenSource returns a token source yielding DefaultToken.
+func (s *Server) TokenSource() oauth2.TokenSource {
+	return oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: DefaultToken,
+		TokenType:   "Bearer",
+	})
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// AddAccount seeds a brokerage account and returns it with its URLs filled
+// in.
+func (s *Server) AddAccount(a Account) Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a.AccountNumber == "" {
+		a.AccountNumber = fmt.Sprintf("5RY%05d", len(s.accounts)+1)
+	}
+	if a.Type == "" {
+		a.Type = "margin"
+	}
+	if a.BrokerageAccountType == "" {
+		a.BrokerageAccountType = "individual"
+	}
+	a.URL = s.url("accounts/%s/", a.AccountNumber)
+	a.Portfolio = s.url("accounts/%s/portfolio/", a.AccountNumber)
+	a.Positions = s.url("accounts/%s/positions/", a.AccountNumber)
+	a.User = s.url("user/")
+	if a.CreatedAt == "" {
+		a.CreatedAt = s.timestamp()
+	}
+	if a.UpdatedAt == "" {
+		a.UpdatedAt = a.CreatedAt
+	}
+	s.accounts = append(s.accounts, a)
+	return a
+}
+
+// AddCryptoAccount seeds a crypto account and returns it.
+func (s *Server) AddCryptoAccount(a CryptoAccount) CryptoAccount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a.ID == "" {
+		a.ID = s.newID()
+	}
+	if a.Status == "" {
+		a.Status = "active"
+	}
+	s.cryptoAccounts = append(s.cryptoAccounts, a)
+	return a
+}
+
+// AddInstrument seeds an equity instrument and returns it with its ID and
+// URLs filled in.
+func (s *Server) AddInstrument(i Instrument) Instrument {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i.ID == "" {
+		i.ID = s.newID()
+	}
+	if i.State == "" {
+		i.State = "active"
+	}
+	if i.Tradability == "" {
+		i.Tradability = "tradable"
+		i.Tradeable = true
+	}
+	if i.Type == "" {
+		i.Type = "stock"
+	}
+	i.URL = s.url("instruments/%s/", i.ID)
+	i.Quote = s.url("quotes/%s/", i.Symbol)
+	i.Fundamentals = s.url("fundamentals/%s/", i.Symbol)
+	s.instruments = append(s.instruments, i)
+	return i
+}
+
+// SetQuote seeds or replaces the quote for q.Symbol.
+func (s *Server) SetQuote(q Quote) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if q.UpdatedAt == "" {
+		q.UpdatedAt = s.timestamp()
+	}
+	for _, i := range s.instruments {
+		if i.Symbol == q.Symbol {
+			q.Instrument = i.URL
+		}
+	}
+	s.quotes[q.Symbol] = q
+}
+
+// AddOptionChain seeds an option chain and returns it with its ID and
+// underlying instrument filled in.
+func (s *Server) AddOptionChain(c OptionChain) OptionChain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.ID == "" {
+		c.ID = s.newID()
+	}
+	if c.TradeValueMultiplier == "" {
+		c.TradeValueMultiplier = "100.0000"
+	}
+	if c.EquityInstrumentID != "" && len(c.UnderlyingInstruments) == 0 {
+		c.UnderlyingInstruments = []UnderlyingInstrument{{
+			ID:         s.newID(),
+			Instrument: s.url("instruments/%s/", c.EquityInstrumentID),
+			Quantity:   100,
+		}}
+	}
+	s.chains = append(s.chains, c)
+	return c
+}
+
+// AddOptionInstrument seeds an option contract and returns it with its ID
+// and URL filled in. The expiration date is added to the chain's expiration
+// dates if the chain has been seeded.
+func (s *Server) AddOptionInstrument(o OptionInstrument) OptionInstrument {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if o.ID == "" {
+		o.ID = s.newID()
+	}
+	if o.State == "" {
+		o.State = "active"
+	}
+	if o.Tradability == "" {
+		o.Tradability = "tradable"
+	}
+	if o.CreatedAt == "" {
+		o.CreatedAt = s.timestamp()
+	}
+	if o.UpdatedAt == "" {
+		o.UpdatedAt = o.CreatedAt
+	}
+	o.URL = s.url("options/instruments/%s/", o.ID)
+	for i := range s.chains {
+		c := &s.chains[i]
+		if c.ID != o.ChainID {
+			continue
+		}
+		if o.ChainSymbol == "" {
+			o.ChainSymbol = c.Symbol
+		}
+		if o.MinTicks == (MinTicks{}) {
+			o.MinTicks = c.MinTicks
+		}
+		if !contains(c.ExpirationDates, o.ExpirationDate) {
+			c.ExpirationDates = append(c.ExpirationDates, o.ExpirationDate)
+			sort.Strings(c.ExpirationDates)
+		}
+	}
+	s.optionInsts = append(s.optionInsts, o)
+	return o
+}
+
+// SetOptionMarketData seeds or replaces the market data for the option
+// instrument with the given ID.
+func (s *Server) SetOptionMarketData(instrumentID string, md OptionMarketData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	md.Instrument = s.url("options/instruments/%s/", instrumentID)
+	if md.PreviousCloseDate == "" {
+		md.PreviousCloseDate = s.Now().Format("2006-01-02")
+	}
+	s.marketData[instrumentID] = md
+}
+
+// AddCurrencyPair seeds a crypto currency pair and returns it with its ID
+// filled in.
+func (s *Server) AddCurrencyPair(p CurrencyPair) CurrencyPair {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p.ID == "" {
+		p.ID = s.newID()
+	}
+	if p.Tradability == "" {
+		p.Tradability = "tradable"
+	}
+	if p.AssetCurrency.ID == "" {
+		p.AssetCurrency.ID = s.newID()
+	}
+	if p.QuoteCurrency.Code == "" {
+		p.QuoteCurrency = Currency{ID: s.newID(), Code: "USD", Name: "US Dollar", Increment: "0.01", Type: "fiat"}
+	}
+	s.currencyPairs = append(s.currencyPairs, p)
+	return p
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		RawQuery: r.URL.RawQuery,
+		Header:   r.Header.Clone(),
+		Body:     body,
+	})
+
+	if s.fault(w, r) {
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	if path == "oauth2/token" && r.Method == http.MethodPost {
+		s.login(w, r)
+		return
+	}
+	if !s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")] {
+		writeError(w, http.StatusUnauthorized, "Authentication credentials were not provided.")
+		return
+	}
+
+	for _, rt := range s.routes() {
+		if rt.method != r.Method {
+			continue
+		}
+		if args, ok := match(rt.pattern, path); ok {
+			rt.handle(w, r, args)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not found.")
+}
+
+type route struct {
+	method, pattern string
+	handle          func(w http.ResponseWriter, r *http.Request, args []string)
+}
+
+func (s *Server) routes() []route {
+	return []route{
+		{"GET", "accounts", s.listAccounts},
+		{"GET", "accounts/*", s.getAccount},
+		{"GET", "accounts/*/positions", s.listPositions},
+		{"GET", "accounts/*/portfolio", s.getPortfolio},
+		{"GET", "positions", s.listPositions},
+		{"GET", "portfolios", s.listPortfolios},
+		{"GET", "instruments", s.listInstruments},
+		{"GET", "instruments/*", s.getInstrument},
+		{"GET", "quotes", s.listQuotes},
+		{"GET", "orders", s.listOrders(s.orders)},
+		{"POST", "orders", s.createOrder},
+		{"GET", "orders/*", s.getOrder(s.orders)},
+		{"POST", "orders/*/cancel", s.cancelOrder(s.orders)},
+		{"GET", "options/chains", s.listOptionChains},
+		{"GET", "options/chains/*", s.getOptionChain},
+		{"GET", "options/instruments", s.listOptionInstruments},
+		{"GET", "options/instruments/*", s.getOptionInstrument},
+		{"GET", "options/aggregate_positions", s.listOptionPositions},
+		{"GET", "options/orders", s.listOrders(s.optionOrders)},
+		{"POST", "options/orders", s.createOptionOrder},
+		{"GET", "options/orders/*", s.getOrder(s.optionOrders)},
+		{"POST", "options/orders/*/cancel", s.cancelOrder(s.optionOrders)},
+		{"GET", "marketdata/options", s.listOptionMarketData},
+		{"GET", "nummus/accounts", s.listCryptoAccounts},
+		{"GET", "nummus/currency_pairs", s.listCurrencyPairs},
+		{"GET", "nummus/orders", s.listOrders(s.cryptoOrders)},
+		{"POST", "nummus/orders", s.createCryptoOrder},
+		{"GET", "nummus/orders/*", s.getOrder(s.cryptoOrders)},
+		{"POST", "nummus/orders/*/cancel", s.cancelOrder(s.cryptoOrders)},
+	}
+}
+
+// match reports whether path matches pattern, where each "*" segment of
+// pattern matches exactly one path segment, and returns the matched segments.
+func match(pattern, path string) ([]string, bool) {
+	ps, segs := strings.Split(pattern, "/"), strings.Split(path, "/")
+	if len(ps) != len(segs) {
+		return nil, false
+	}
+	var args []string
+	for i := range ps {
+		switch {
+		case ps[i] == "*" && segs[i] != "":
+			args = append(args, segs[i])
+		case ps[i] != segs[i]:
+			return nil, false
+		}
+	}
+	return args, true
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if r.PostForm.Get("username") != s.Username || r.PostForm.Get("password") != s.Password {
+		writeError(w, http.StatusBadRequest, "Unable to log in with provided credentials.")
+		return
+	}
+	if s.MFA != "" && strings.TrimSpace(r.PostForm.Get("mfa_code")) != s.MFA {
+		// Like the real API, ask for the code with a 400.
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"mfa_required": true,
+			"mfa_type":     "sms",
+		})
+		return
+	}
+	s.ids++
+	tok := fmt.Sprintf("robinhoodtest-access-%d", s.ids)
+	s.tokens[tok] = true
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  tok,
+		"refresh_token": fmt.Sprintf("robinhoodtest-refresh-%d", s.ids),
+		"token_type":    "Bearer",
+		"expires_in":    86400,
+		"scope":         "internal",
+	})
+}
+
+func (s *Server) listAccounts(w http.ResponseWriter, r *http.Request, _ []string) {
+	items := make([]interface{}, len(s.accounts))
+	for i := range s.accounts {
+		items[i] = s.accounts[i]
+	}
+	s.writePage(w, r, items)
+}
+
+func (s *Server) getAccount(w http.ResponseWriter, r *http.Request, args []string) {
+	for _, a := range s.accounts {
+		if a.AccountNumber == args[0] {
+			writeJSON(w, http.StatusOK, a)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not found.")
+}
+
+func (s *Server) listInstruments(w http.ResponseWriter, r *http.Request, _ []string) {
+	sym := r.URL.Query().Get("symbol")
+	var items []interface{}
+	for _, i := range s.instruments {
+		if sym == "" || strings.EqualFold(sym, i.Symbol) {
+			items = append(items, i)
+		}
+	}
+	s.writePage(w, r, items)
+}
+
+func (s *Server) getInstrument(w http.ResponseWriter, r *http.Request, args []string) {
+	for _, i := range s.instruments {
+		if i.ID == args[0] {
+			writeJSON(w, http.StatusOK, i)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not found.")
+}
+
+func (s *Server) listQuotes(w http.ResponseWriter, r *http.Request, _ []string) {
+	var results []interface{}
+	for _, sym := range splitList(r.URL.Query().Get("symbols")) {
+		if q, ok := s.quotes[strings.ToUpper(sym)]; ok {
+			results = append(results, q)
+		} else {
+			results = append(results, nil)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+}
+
+func (s *Server) listOptionChains(w http.ResponseWriter, r *http.Request, _ []string) {
+	ids := splitList(r.URL.Query().Get("equity_instrument_ids"))
+	var items []interface{}
+	for _, c := range s.chains {
+		if len(ids) == 0 || contains(ids, c.EquityInstrumentID) {
+			items = append(items, c)
+		}
+	}
+	s.writePage(w, r, items)
+}
+
+func (s *Server) getOptionChain(w http.ResponseWriter, r *http.Request, args []string) {
+	for _, c := range s.chains {
+		if c.ID == args[0] {
+			writeJSON(w, http.StatusOK, c)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not found.")
+}
+
+func (s *Server) listOptionInstruments(w http.ResponseWriter, r *http.Request, _ []string) {
+	q := r.URL.Query()
+	dates := splitList(q.Get("expiration_dates"))
+	ids := splitList(q.Get("ids"))
+	var insts []OptionInstrument
+	for _, o := range s.optionInsts {
+		switch {
+		case q.Get("chain_id") != "" && o.ChainID != q.Get("chain_id"),
+			q.Get("type") != "" && o.Type != q.Get("type"),
+			q.Get("state") != "" && o.State != q.Get("state"),
+			q.Get("tradability") != "" && o.Tradability != q.Get("tradability"),
+			len(dates) > 0 && !contains(dates, o.ExpirationDate),
+			len(ids) > 0 && !contains(ids, o.ID):
+			continue
+		}
+		insts = append(insts, o)
+	}
+	sort.SliceStable(insts, func(i, j int) bool {
+		if insts[i].ExpirationDate != insts[j].ExpirationDate {
+			return insts[i].ExpirationDate < insts[j].ExpirationDate
+		}
+		a, _ := strconv.ParseFloat(insts[i].StrikePrice, 64)
+		b, _ := strconv.ParseFloat(insts[j].StrikePrice, 64)
+		return a < b
+	})
+	items := make([]interface{}, len(insts))
+	for i := range insts {
+		items[i] = insts[i]
+	}
+	s.writePage(w, r, items)
+}
+
+func (s *Server) getOptionInstrument(w http.ResponseWriter, r *http.Request, args []string) {
+	for _, o := range s.optionInsts {
+		if o.ID == args[0] {
+			writeJSON(w, http.StatusOK, o)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not found.")
+}
+
+func (s *Server) listOptionMarketData(w http.ResponseWriter, r *http.Request, _ []string) {
+	var results []interface{}
+	for _, u := range splitList(r.URL.Query().Get("instruments")) {
+		if md, ok := s.marketData[lastSegment(u)]; ok {
+			results = append(results, md)
+		} else {
+			results = append(results, nil)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+}
+
+func (s *Server) listCryptoAccounts(w http.ResponseWriter, r *http.Request, _ []string) {
+	items := make([]interface{}, len(s.cryptoAccounts))
+	for i := range s.cryptoAccounts {
+		items[i] = s.cryptoAccounts[i]
+	}
+	s.writePage(w, r, items)
+}
+
+func (s *Server) listCurrencyPairs(w http.ResponseWriter, r *http.Request, _ []string) {
+	items := make([]interface{}, len(s.currencyPairs))
+	for i := range s.currencyPairs {
+		items[i] = s.currencyPairs[i]
+	}
+	s.writePage(w, r, items)
+}
+
+// writePage writes one page of items selected by the "cursor" query
+// parameter, with absolute next and previous links.
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, items []interface{}) {
+	size := s.PageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+	if start < 0 || start > len(items) {
+		start = len(items)
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+
+	page := map[string]interface{}{
+		"results":  append([]interface{}{}, items[start:end]...),
+		"next":     nil,
+		"previous": nil,
+	}
+	if end < len(items) {
+		page["next"] = s.pageURL(r, end)
+	}
+	if start > 0 {
+		prev := start - size
+		if prev < 0 {
+			prev = 0
+		}
+		page["previous"] = s.pageURL(r, prev)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) pageURL(r *http.Request, cursor int) string {
+	q := r.URL.Query()
+	q.Set("cursor", strconv.Itoa(cursor))
+	return s.srv.URL + r.URL.Path + "?" + q.Encode()
+}
+
+// url returns the absolute URL of an API path.
+func (s *Server) url(format string, args ...interface{}) string {
+	return s.srv.URL + "/" + fmt.Sprintf(format, args...)
+}
+
+// newID returns a deterministic, UUID-shaped identifier. s.mu must be held.
+func (s *Server) newID() string {
+	s.ids++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.ids)
+}
+
+func (s *Server) timestamp() string {
+	return s.Now().UTC().Format(time.RFC3339Nano)
+}
+
+// writeJSON writes v as the response, rendering empty strings as null the way
+// the real API does for missing values.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var generic interface{}
+	if err := json.Unmarshal(bs, &generic); err == nil {
+		bs, _ = json.Marshal(nullEmpty(generic))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(bs)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]interface{}{"detail": detail})
+}
+
+func nullEmpty(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = nullEmpty(v[k])
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = nullEmpty(v[i])
+		}
+	}
+	return v
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// lastSegment returns the final path segment of a resource URL such as
+// https://api.robinhood.com/options/instruments/<id>/.
+func lastSegment(u string) string {
+	if parsed, err := url.Parse(u); err == nil {
+		u = parsed.Path
+	}
+	u = strings.TrimSuffix(u, "/")
+	return u[strings.LastIndex(u, "/")+1:]
+}
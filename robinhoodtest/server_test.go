@@ -0,0 +1,170 @@
+package robinhoodtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nikunjy/robinhood"
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func dial(t *testing.T, srv *robinhoodtest.Server) *robinhood.Client {
+	c, err := robinhood.Dial(context.Background(), srv.TokenSource(),
+		robinhood.WithBaseURLs(srv.URL(), srv.CryptoURL()))
+	require.NoError(t, err)
+	return c
+}
+
+func TestLogin(t *testing.T) {
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.Username, srv.Password, srv.MFA = "user", "pass", "123456"
+
+	o := &robinhood.OAuth{Username: "user", Password: "pass", BaseURL: srv.URL()}
+	_, err := o.Token()
+	require.Equal(t, robinhood.ErrMFARequired, err)
+
+	// The deprecated Endpoint is ignored.
+	o.MFA, o.Endpoint = "123456", "https://api.robinhood.com/oauth2/token/"
+	tok, err := o.Token()
+	require.NoError(t, err)
+	require.NotEmpty(t, tok.AccessToken)
+
+	c, err := robinhood.Dial(context.Background(), o, robinhood.WithBaseURLs(srv.URL(), srv.CryptoURL()))
+	require.NoError(t, err)
+	require.Equal(t, robinhoodtest.DefaultAccountNumber, c.Account.AccountNumber)
+}
+
+func TestOrders(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 2
+	srv.AddInstrument(robinhoodtest.Instrument{Symbol: "SPY", Name: "SPDR S&P 500 ETF"})
+	srv.SetQuote(robinhoodtest.Quote{Symbol: "SPY", BidPrice: "400.01", AskPrice: "400.03", LastTradePrice: "400.02"})
+	c := dial(t, srv)
+
+	i, err := c.GetInstrumentForSymbol(ctx, "SPY")
+	require.NoError(t, err)
+	qs, err := c.GetQuote(ctx, "SPY")
+	require.NoError(t, err)
+	require.Len(t, qs, 1)
+	require.Equal(t, "400.01", qs[0].BidPrice.String())
+
+	var ids []string
+	for n := 0; n < 5; n++ {
+		out, err := c.Order(ctx, i, robinhood.OrderOpts{
+			Side:     robinhood.Buy,
+			Type:     robinhood.Limit,
+			Quantity: robinhood.NewDecimal(1, 0),
+			Price:    robinhood.NewDecimal(400, 0),
+		})
+		require.NoError(t, err)
+		require.Equal(t, robinhood.Queued, out.State)
+		ids = append(ids, out.ID)
+	}
+
+	all, err := c.AllOrders(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+	require.Equal(t, ids[4], all[0].ID)
+
+	out := all[0]
+	require.NoError(t, srv.FillOrder(out.ID, "1", "399.5"))
+	require.NoError(t, out.Update(ctx))
+	require.Equal(t, robinhood.Filled, out.State)
+	require.Equal(t, "399.5", out.AveragePrice.String())
+	require.Len(t, out.Executions, 1)
+	require.Equal(t, "399.5", out.Executions[0].Price.String())
+	require.Equal(t, "1", out.Executions[0].Quantity.String())
+	require.False(t, out.Executions[0].Timestamp.IsZero())
+	require.Error(t, out.Cancel(ctx))
+
+	require.NoError(t, all[1].Cancel(ctx))
+	ord, ok := srv.Order(all[1].ID)
+	require.True(t, ok)
+	require.Equal(t, "cancelled", ord["state"])
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.OrderLifecycle = []string{"unconfirmed", "confirmed", "filled"}
+	i := srv.AddInstrument(robinhoodtest.Instrument{Symbol: "AMD"})
+	c := dial(t, srv)
+
+	out, err := c.Order(ctx, &robinhood.Instrument{URL: i.URL, Symbol: i.Symbol}, robinhood.OrderOpts{
+		Side: robinhood.Sell, Type: robinhood.Limit, Quantity: robinhood.NewDecimal(3, 0), Price: robinhood.NewDecimal(80, 0),
+	})
+	require.NoError(t, err)
+	for _, want := range []robinhood.OrderState{robinhood.Confirmed, robinhood.Filled, robinhood.Filled} {
+		require.NoError(t, out.Update(ctx))
+		require.Equal(t, want, out.State)
+	}
+	require.Equal(t, "3", out.CumulativeQuantity.String())
+}
+
+func TestOptions(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 1
+	spy := srv.AddInstrument(robinhoodtest.Instrument{Symbol: "SPY"})
+	chain := srv.AddOptionChain(robinhoodtest.OptionChain{
+		Symbol:             "SPY",
+		EquityInstrumentID: spy.ID,
+		MinTicks:           robinhoodtest.MinTicks{AboveTick: "0.05", BelowTick: "0.01", CutoffPrice: "3.00"},
+	})
+	for _, strike := range []string{"410.0000", "400.0000"} {
+		o := srv.AddOptionInstrument(robinhoodtest.OptionInstrument{
+			ChainID: chain.ID, ExpirationDate: "2021-03-19", StrikePrice: strike, Type: "call",
+		})
+		srv.SetOptionMarketData(o.ID, robinhoodtest.OptionMarketData{MarkPrice: "1.25", Delta: "0.5"})
+	}
+	c := dial(t, srv)
+
+	i, err := c.GetInstrumentForSymbol(ctx, "SPY")
+	require.NoError(t, err)
+	chains, err := c.GetOptionChains(ctx, i)
+	require.NoError(t, err)
+	require.Len(t, chains, 1)
+	require.Equal(t, []string{"2021-03-19"}, chains[0].ExpirationDates)
+	require.Equal(t, "0.05", chains[0].MinTicks.AboveTick.String())
+
+	insts, err := chains[0].GetInstrument(ctx, "call", robinhood.NewDate(2021, 3, 19))
+	require.NoError(t, err)
+	require.Len(t, insts, 2)
+	require.Equal(t, "400", insts[0].StrikePrice.String())
+
+	md, err := c.MarketData(ctx, insts...)
+	require.NoError(t, err)
+	require.Len(t, md, 2)
+	require.Equal(t, "1.25", md[0].MarkPrice.String())
+}
+
+func TestCrypto(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.PageSize = 1
+	srv.AddCurrencyPair(robinhoodtest.CurrencyPair{
+		Symbol:        "BTC-USD",
+		AssetCurrency: robinhoodtest.Currency{Code: "BTC", Name: "Bitcoin", Increment: "0.00000001"},
+	})
+	c := dial(t, srv)
+
+	pairs, err := c.GetCryptoCurrencyPairs(ctx)
+	require.NoError(t, err)
+	require.Len(t, pairs, 1)
+
+	pair, err := c.GetCryptoInstrument(ctx, "BTC")
+	require.NoError(t, err)
+	out, err := c.CryptoOrder(ctx, *pair, robinhood.CryptoOrderOpts{
+		Side: robinhood.Buy, Type: robinhood.Limit, AmountInDollars: robinhood.NewDecimal(100, 0), Price: robinhood.NewDecimal(50, 0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, c.CryptoAccount.ID, out.Account)
+	require.NoError(t, out.Cancel(ctx))
+}
@@ -0,0 +1,222 @@
+package robinhoodtest
+
+// The types in this file seed the fake server. Their JSON tags mirror the
+// payloads of the real API; numeric fields are strings, as Robinhood sends
+// them, and empty strings are rendered as null. URL fields are filled in by
+// the server and may be left empty.
+
+// Account is a brokerage account served from /accounts/.
+type Account struct {
+	AccountNumber        string `json:"account_number"`
+	Type                 string `json:"type"`
+	BrokerageAccountType string `json:"brokerage_account_type"`
+	BuyingPower          string `json:"buying_power"`
+	Cash                 string `json:"cash"`
+	Deactivated          bool   `json:"deactivated"`
+	URL                  string `json:"url"`
+	Portfolio            string `json:"portfolio"`
+	Positions            string `json:"positions"`
+	User                 string `json:"user"`
+	CreatedAt            string `json:"created_at"`
+	UpdatedAt            string `json:"updated_at"`
+}
+
+// CryptoAccount is a nummus account served from /nummus/accounts/.
+type CryptoAccount struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	UserID string `json:"user_id"`
+}
+
+// Instrument is an equity instrument served from /instruments/.
+type Instrument struct {
+	ID                    string `json:"id"`
+	Symbol                string `json:"symbol"`
+	Name                  string `json:"name"`
+	SimpleName            string `json:"simple_name"`
+	Type                  string `json:"type"`
+	State                 string `json:"state"`
+	Tradeable             bool   `json:"tradeable"`
+	Tradability           string `json:"tradability"`
+	FractionalTradability string `json:"fractional_tradability"`
+	MinTickSize           string `json:"min_tick_size"`
+	TradableChainID       string `json:"tradable_chain_id"`
+	Country               string `json:"country"`
+	URL                   string `json:"url"`
+	Quote                 string `json:"quote"`
+	Fundamentals          string `json:"fundamentals"`
+}
+
+// Quote is a stock quote served from /quotes/.
+type Quote struct {
+	Symbol                      string `json:"symbol"`
+	AskPrice                    string `json:"ask_price"`
+	AskSize                     int    `json:"ask_size"`
+	BidPrice                    string `json:"bid_price"`
+	BidSize                     int    `json:"bid_size"`
+	LastTradePrice              string `json:"last_trade_price"`
+	LastExtendedHoursTradePrice string `json:"last_extended_hours_trade_price"`
+	PreviousClose               string `json:"previous_close"`
+	AdjustedPreviousClose       string `json:"adjusted_previous_close"`
+	PreviousCloseDate           string `json:"previous_close_date"`
+	TradingHalted               bool   `json:"trading_halted"`
+	UpdatedAt                   string `json:"updated_at"`
+	Instrument                  string `json:"instrument"`
+}
+
+// MinTicks are the price increments of an option chain or instrument.
+type MinTicks struct {
+	AboveTick   string `json:"above_tick"`
+	BelowTick   string `json:"below_tick"`
+	CutoffPrice string `json:"cutoff_price"`
+}
+
+// UnderlyingInstrument links an option chain back to its equity.
+type UnderlyingInstrument struct {
+	ID         string `json:"id"`
+	Instrument string `json:"instrument"`
+	Quantity   int    `json:"quantity"`
+}
+
+// OptionChain is served from /options/chains/. EquityInstrumentID is the ID
+// of the seeded Instrument the chain belongs to.
+type OptionChain struct {
+	ID                    string                 `json:"id"`
+	Symbol                string                 `json:"symbol"`
+	CanOpenPosition       bool                   `json:"can_open_position"`
+	ExpirationDates       []string               `json:"expiration_dates"`
+	MinTicks              MinTicks               `json:"min_ticks"`
+	TradeValueMultiplier  string                 `json:"trade_value_multiplier"`
+	UnderlyingInstruments []UnderlyingInstrument `json:"underlying_instruments"`
+
+	EquityInstrumentID string `json:"-"`
+}
+
+// OptionInstrument is a single contract served from /options/instruments/.
+type OptionInstrument struct {
+	ID             string   `json:"id"`
+	ChainID        string   `json:"chain_id"`
+	ChainSymbol    string   `json:"chain_symbol"`
+	ExpirationDate string   `json:"expiration_date"`
+	StrikePrice    string   `json:"strike_price"`
+	Type           string   `json:"type"`
+	State          string   `json:"state"`
+	Tradability    string   `json:"tradability"`
+	RHSTradability string   `json:"rhs_tradability"`
+	MinTicks       MinTicks `json:"min_ticks"`
+	IssueDate      string   `json:"issue_date"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+	URL            string   `json:"url"`
+}
+
+// OptionMarketData is served from /marketdata/options/ for the option
+// instrument it is seeded for.
+type OptionMarketData struct {
+	AdjustedMarkPrice   string `json:"adjusted_mark_price"`
+	AskPrice            string `json:"ask_price"`
+	AskSize             int    `json:"ask_size"`
+	BidPrice            string `json:"bid_price"`
+	BidSize             int    `json:"bid_size"`
+	BreakEvenPrice      string `json:"break_even_price"`
+	ChanceOfProfitLong  string `json:"chance_of_profit_long"`
+	ChanceOfProfitShort string `json:"chance_of_profit_short"`
+	Delta               string `json:"delta"`
+	Gamma               string `json:"gamma"`
+	Rho                 string `json:"rho"`
+	Theta               string `json:"theta"`
+	Vega                string `json:"vega"`
+	ImpliedVolatility   string `json:"implied_volatility"`
+	HighPrice           string `json:"high_price"`
+	LowPrice            string `json:"low_price"`
+	LastTradePrice      string `json:"last_trade_price"`
+	LastTradeSize       int    `json:"last_trade_size"`
+	MarkPrice           string `json:"mark_price"`
+	OpenInterest        int    `json:"open_interest"`
+	PreviousCloseDate   string `json:"previous_close_date"`
+	PreviousClosePrice  string `json:"previous_close_price"`
+	Volume              int    `json:"volume"`
+	Instrument          string `json:"instrument"`
+}
+
+// Currency is either side of a CurrencyPair.
+type Currency struct {
+	ID         string `json:"id"`
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Increment  string `json:"increment"`
+	Type       string `json:"type,omitempty"`
+	BrandColor string `json:"brand_color,omitempty"`
+}
+
+// CurrencyPair is a tradeable crypto pair served from /nummus/currency_pairs/.
+type CurrencyPair struct {
+	ID                     string   `json:"id"`
+	Symbol                 string   `json:"symbol"`
+	Name                   string   `json:"name"`
+	Tradability            string   `json:"tradability"`
+	MinOrderSize           string   `json:"min_order_size"`
+	MaxOrderSize           string   `json:"max_order_size"`
+	MinOrderPriceIncrement string   `json:"min_order_price_increment"`
+	AssetCurrency          Currency `json:"asset_currency"`
+	QuoteCurrency          Currency `json:"quote_currency"`
+}
+
+// Position is an equity position served from /positions/ and the positions
+// URL of its account.
+type Position struct {
+	Account                 string `json:"account"`
+	Instrument              string `json:"instrument"`
+	Quantity                string `json:"quantity"`
+	AverageBuyPrice         string `json:"average_buy_price"`
+	IntradayAverageBuyPrice string `json:"intraday_average_buy_price"`
+	IntradayQuantity        string `json:"intraday_quantity"`
+	SharesHeldForBuys       string `json:"shares_held_for_buys"`
+	SharesHeldForSells      string `json:"shares_held_for_sells"`
+	URL                     string `json:"url"`
+	CreatedAt               string `json:"created_at"`
+	UpdatedAt               string `json:"updated_at"`
+}
+
+// OptionPosition is an options position served from
+// /options/aggregate_positions/. Quantity is the number of units of the
+// strategy held, each made up of RatioQuantity contracts of every leg.
+type OptionPosition struct {
+	ID                   string              `json:"id"`
+	Account              string              `json:"account"`
+	Chain                string              `json:"chain"`
+	Symbol               string              `json:"symbol"`
+	Strategy             string              `json:"strategy"`
+	Direction            string              `json:"direction"`
+	Quantity             string              `json:"quantity"`
+	AverageOpenPrice     string              `json:"average_open_price"`
+	TradeValueMultiplier string              `json:"trade_value_multiplier"`
+	Legs                 []OptionPositionLeg `json:"legs"`
+	CreatedAt            string              `json:"created_at"`
+	UpdatedAt            string              `json:"updated_at"`
+}
+
+// OptionPositionLeg is one leg of an OptionPosition. PositionType is "long"
+// or "short". AddOptionPosition defaults RatioQuantity to 1 and
+// ExpirationDate to today.
+type OptionPositionLeg struct {
+	ID             string `json:"id"`
+	Option         string `json:"option"`
+	PositionType   string `json:"position_type"`
+	RatioQuantity  int    `json:"ratio_quantity"`
+	ExpirationDate string `json:"expiration_date"`
+	StrikePrice    string `json:"strike_price"`
+	OptionType     string `json:"option_type"`
+}
+
+// Portfolio is the portfolio of an account, served from /portfolios/ and the
+// portfolio URL of the account.
+type Portfolio struct {
+	Account             string `json:"account"`
+	Equity              string `json:"equity"`
+	ExtendedHoursEquity string `json:"extended_hours_equity"`
+	MarketValue         string `json:"market_value"`
+	WithdrawableAmount  string `json:"withdrawable_amount"`
+	StartDate           string `json:"start_date"`
+	URL                 string `json:"url"`
+}
@@ -0,0 +1,145 @@
+package robinhood
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TickPolicy decides what happens to order prices and quantities that are
+// not a multiple of the increment the API accepts for them.
+type TickPolicy int
+
+// Tick policies. The default is TickReject.
+const (
+	// TickReject fails the order with a *ValidationError.
+	TickReject TickPolicy = iota
+	// TickRound rounds prices to the nearest increment and quantities down
+	// to one, so that an order is never made bigger, failing only if that
+	// makes the value zero.
+	TickRound
+)
+
+// A ValidationError is returned when an order is rejected by the client
+// before it is submitted.
+type ValidationError struct {
+	// Field is the order field at fault, e.g. "price" or "quantity".
+	Field string
+	// Value is the rejected value, if the field has one.
+	Value string
+	// Reason describes what is wrong with it.
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("invalid order %s: %s", e.Field, e.Reason)
+	}
+	return fmt.Sprintf("invalid order %s %s: %s", e.Field, e.Value, e.Reason)
+}
+
+// IsValidationError reports whether err is a *ValidationError.
+func IsValidationError(err error) bool {
+	var e *ValidationError
+	return errors.As(err, &e)
+}
+
+// Equity price increments used when an instrument has no MinTickSize:
+// sub-penny prices are only accepted below $1.
+var (
+	equityTick      = NewDecimal(1, 2)
+	equitySubPenny  = NewDecimal(1, 4)
+	equitySubCutoff = NewDecimal(1, 0)
+)
+
+// Quantity increments of option and equity orders.
+var (
+	wholeContract   = NewDecimal(1, 0)
+	wholeShare      = NewDecimal(1, 0)
+	fractionalShare = NewDecimal(1, 6)
+)
+
+// PriceTick returns the price increment of the instrument at the given
+// price, i.e. MinTickSize or, if that is not set, $0.01 for prices of $1 and
+// above and $0.0001 below.
+func (i Instrument) PriceTick(price Decimal) Decimal {
+	if !i.MinTickSize.IsZero() {
+		return i.MinTickSize
+	}
+	if price.Cmp(equitySubCutoff) < 0 {
+		return equitySubPenny
+	}
+	return equityTick
+}
+
+// Tick returns the price increment at the given price: AboveTick at or
+// above CutoffPrice, and BelowTick below it. If either is not set, the other
+// is used.
+func (m MinTicks) Tick(price Decimal) Decimal {
+	tick := m.AboveTick
+	if !m.CutoffPrice.IsZero() && price.Cmp(m.CutoffPrice) < 0 {
+		tick = m.BelowTick
+	}
+	if tick.IsZero() {
+		tick = m.AboveTick
+	}
+	if tick.IsZero() {
+		tick = m.BelowTick
+	}
+	return tick
+}
+
+// IsMultipleOf reports whether d is a whole multiple of inc. Every number is
+// a multiple of a zero increment.
+func (d Decimal) IsMultipleOf(inc Decimal) bool {
+	return inc.IsZero() || d.RoundToTick(inc).Equal(d)
+}
+
+// checkIncrement returns v, or v rounded to inc with round according to the
+// client's TickPolicy.
+func (c *Client) checkIncrement(field string, v, inc Decimal, round func(v, inc Decimal) Decimal) (Decimal, error) {
+	if v.IsMultipleOf(inc) {
+		return v, nil
+	}
+	if c.Ticks == TickRound {
+		if r := round(v, inc); !r.IsZero() {
+			return r, nil
+		}
+		return v, &ValidationError{Field: field, Value: v.String(), Reason: fmt.Sprintf("rounds to zero at increment %s", inc)}
+	}
+	return v, &ValidationError{Field: field, Value: v.String(), Reason: fmt.Sprintf("not a multiple of %s", inc)}
+}
+
+// checkPrice validates an order price against tick. A zero price is only
+// accepted if it is not required, e.g. for market orders.
+func (c *Client) checkPrice(price, tick Decimal, required bool) (Decimal, error) {
+	switch {
+	case price.Sign() < 0:
+		return price, &ValidationError{Field: "price", Value: price.String(), Reason: "must not be negative"}
+	case price.IsZero() && required:
+		return price, &ValidationError{Field: "price", Value: price.String(), Reason: "required for limit and stop orders"}
+	case price.IsZero():
+		return price, nil
+	}
+	return c.checkIncrement("price", price, tick, Decimal.RoundToTick)
+}
+
+// checkQuantity validates a quantity, which must be positive, against inc.
+// Quantities are only ever rounded down.
+func (c *Client) checkQuantity(qty, inc Decimal) (Decimal, error) {
+	if qty.Sign() <= 0 {
+		return qty, &ValidationError{Field: "quantity", Value: qty.String(), Reason: "must be positive"}
+	}
+	return c.checkIncrement("quantity", qty, inc, Decimal.TruncateToTick)
+}
+
+// checkOrderSize validates a crypto order quantity against the pair's
+// minimum and maximum order sizes, where set.
+func checkOrderSize(qty Decimal, p CryptoCurrencyPair) error {
+	if !p.MinOrderSize.IsZero() && qty.Cmp(p.MinOrderSize) < 0 {
+		return &ValidationError{Field: "quantity", Value: qty.String(), Reason: fmt.Sprintf("below the minimum order size of %s", p.MinOrderSize)}
+	}
+	if !p.MaxOrderSize.IsZero() && qty.Cmp(p.MaxOrderSize) > 0 {
+		return &ValidationError{Field: "quantity", Value: qty.String(), Reason: fmt.Sprintf("above the maximum order size of %s", p.MaxOrderSize)}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package robinhood
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicks(t *testing.T) {
+	m := MinTicks{AboveTick: MustParseDecimal("0.05"), BelowTick: MustParseDecimal("0.01"), CutoffPrice: NewDecimal(3, 0)}
+	require.Equal(t, "0.01", m.Tick(MustParseDecimal("2.99")).String())
+	require.Equal(t, "0.05", m.Tick(NewDecimal(3, 0)).String())
+	require.Equal(t, "0.05", MinTicks{AboveTick: MustParseDecimal("0.05")}.Tick(NewDecimal(1, 0)).String())
+
+	require.Equal(t, "0.01", Instrument{}.PriceTick(NewDecimal(1, 0)).String())
+	require.Equal(t, "0.0001", Instrument{}.PriceTick(MustParseDecimal("0.5")).String())
+	require.Equal(t, "0.05", Instrument{MinTickSize: MustParseDecimal("0.05")}.PriceTick(NewDecimal(1, 0)).String())
+
+	require.True(t, MustParseDecimal("1.25").IsMultipleOf(MustParseDecimal("0.05")))
+	require.False(t, MustParseDecimal("1.26").IsMultipleOf(MustParseDecimal("0.05")))
+	require.True(t, MustParseDecimal("1.26").IsMultipleOf(Decimal{}))
+}
+
+func TestOrderValidation(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+	inst := &Instrument{URL: srv.URL() + "instruments/x/", MinTickSize: MustParseDecimal("0.05")}
+
+	_, err := c.Order(ctx, inst, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("10.01")})
+	require.True(t, IsValidationError(err))
+	require.EqualError(t, err, "invalid order price 10.01: not a multiple of 0.05")
+	_, err = c.Order(ctx, inst, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0)})
+	require.EqualError(t, err, "invalid order price 0: required for limit and stop orders")
+	_, err = c.Order(ctx, inst, OrderOpts{Side: Buy, Type: Market})
+	require.True(t, IsValidationError(err))
+	require.Equal(t, 0, countRequests(srv, "POST", "/orders/"))
+
+	c.Ticks = TickRound
+	out, err := c.Order(ctx, inst, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("10.01")})
+	require.NoError(t, err)
+	require.Equal(t, "10", out.Price.String())
+	_, err = c.Order(ctx, inst, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("0.02")})
+	require.EqualError(t, err, "invalid order price 0.02: rounds to zero at increment 0.05")
+	require.Equal(t, 1, countRequests(srv, "POST", "/orders/"))
+
+	// Quantities are rounded down, never up.
+	out, err = c.Order(ctx, inst, OrderOpts{Side: Buy, Type: Limit, Quantity: MustParseDecimal("1.5"), Price: NewDecimal(10, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "1", out.Quantity.String())
+}
+
+func TestOptionsOrderValidation(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv, WithTickPolicy(TickRound))
+	oi := &OptionInstrument{
+		URL:      srv.URL() + "options/instruments/x/",
+		MinTicks: MinTicks{AboveTick: MustParseDecimal("0.05"), BelowTick: MustParseDecimal("0.01"), CutoffPrice: NewDecimal(3, 0)},
+	}
+
+	_, err := c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: MustParseDecimal("0.4"), Price: NewDecimal(1, 0)})
+	require.EqualError(t, err, "invalid order quantity 0.4: rounds to zero at increment 1")
+
+	c.Ticks = TickReject
+	_, err = c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("3.01")})
+	require.EqualError(t, err, "invalid order price 3.01: not a multiple of 0.05")
+	require.Equal(t, 0, countRequests(srv, "POST", "/options/orders/"))
+	_, err = c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("2.99")})
+	require.NoError(t, err)
+	require.Equal(t, 1, countRequests(srv, "POST", "/options/orders/"))
+}
+
+func TestCryptoOrderValidation(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+	pair := CryptoCurrencyPair{
+		ID:                     "btc",
+		MinOrderPriceIncrement: MustParseDecimal("0.01"),
+		MinOrderSize:           MustParseDecimal("0.000001"),
+		MaxOrderSize:           NewDecimal(20, 0),
+		CyrptoAssetCurrency:    AssetCurrency{Increment: MustParseDecimal("0.00000001")},
+	}
+
+	_, err := c.CryptoOrder(ctx, pair, CryptoOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(21, 0), Price: NewDecimal(50000, 0)})
+	require.EqualError(t, err, "invalid order quantity 21: above the maximum order size of 20")
+	_, err = c.CryptoOrder(ctx, pair, CryptoOrderOpts{Side: Buy, Type: Limit, AmountInDollars: MustParseDecimal("0.01"), Price: NewDecimal(50000, 0)})
+	require.EqualError(t, err, "invalid order quantity 0.0000002: below the minimum order size of 0.000001")
+	_, err = c.CryptoOrder(ctx, pair, CryptoOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: MustParseDecimal("50000.001")})
+	require.EqualError(t, err, "invalid order price 50000.001: not a multiple of 0.01")
+	require.Equal(t, 0, countRequests(srv, "POST", "/nummus/orders/"))
+
+	out, err := c.CryptoOrder(ctx, pair, CryptoOrderOpts{Side: Buy, Type: Limit, AmountInDollars: NewDecimal(100, 0), Price: NewDecimal(30000, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "0.00333333", out.Quantity.String())
+
+	// 100 / 60000 is 0.0016666..., which would round up to more than $100
+	// worth.
+	out, err = c.CryptoOrder(ctx, pair, CryptoOrderOpts{Side: Buy, Type: Limit, AmountInDollars: NewDecimal(100, 0), Price: NewDecimal(60000, 0)})
+	require.NoError(t, err)
+	require.Equal(t, "0.00166666", out.Quantity.String())
+	require.True(t, out.Quantity.Mul(NewDecimal(60000, 0)).Cmp(NewDecimal(100, 0)) <= 0)
+}
@@ -0,0 +1,111 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxPollInterval caps the backoff between polls of WaitUntilDone, unless
+// the interval it is given is already longer.
+const maxPollInterval = 30 * time.Second
+
+// An OrderRejectedError is returned by WaitUntilDone when an order is
+// rejected or fails.
+type OrderRejectedError struct {
+	// ID is the ID of the order.
+	ID string
+	// State is Rejected or Failed.
+	State OrderState
+	// Reason is the reject reason given by the API, if any.
+	Reason string
+}
+
+func (e *OrderRejectedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("order %s %s", e.ID, e.State)
+	}
+	return fmt.Sprintf("order %s %s: %s", e.ID, e.State, e.Reason)
+}
+
+// WaitUntilDone polls the order until it is filled, cancelled, rejected or
+// failed, waiting interval before the first poll and backing off
+// exponentially after each one in which the state did not change. If changes
+// is not nil, each new state is sent on it; it is not closed.
+//
+// A rejected or failed order returns an *OrderRejectedError. A cancelled
+// order returns nil; check State to tell it from a filled one.
+func (o *OrderOutput) WaitUntilDone(ctx context.Context, interval time.Duration, changes chan<- OrderState) error {
+	return waitUntilDone(ctx, interval, changes, o.ID, func() (OrderState, string) {
+		return o.State, o.RejectReason
+	}, o.Update)
+}
+
+// WaitUntilDone polls the order until it is filled, cancelled, rejected or
+// failed, as OrderOutput.WaitUntilDone does.
+func (o *CryptoOrderOutput) WaitUntilDone(ctx context.Context, interval time.Duration, changes chan<- OrderState) error {
+	return waitUntilDone(ctx, interval, changes, o.ID, func() (OrderState, string) {
+		return o.State, o.RejectReason
+	}, o.Update)
+}
+
+// WaitUntilDone polls the order until it is filled, cancelled, rejected or
+// failed, as OrderOutput.WaitUntilDone does.
+func (o *OptionOrder) WaitUntilDone(ctx context.Context, interval time.Duration, changes chan<- OrderState) error {
+	return waitUntilDone(ctx, interval, changes, o.ID, func() (OrderState, string) {
+		return o.State, o.RejectReason
+	}, o.Update)
+}
+
+// waitUntilDone calls update until status, which returns the order's state
+// and reject reason, is terminal.
+func waitUntilDone(ctx context.Context, interval time.Duration, changes chan<- OrderState, id string,
+	status func() (OrderState, string), update func(context.Context) error) error {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	max := maxPollInterval
+	if interval > max {
+		max = interval
+	}
+
+	state, reason := status()
+	delay := interval
+	for !state.IsTerminal() {
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+
+		if err := update(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		var next OrderState
+		next, reason = status()
+		if next == state {
+			if delay *= 2; delay > max {
+				delay = max
+			}
+			continue
+		}
+		state, delay = next, interval
+		if changes != nil {
+			select {
+			case changes <- state:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	if state == Rejected || state == Failed {
+		return &OrderRejectedError{ID: id, State: state, Reason: reason}
+	}
+	return nil
+}
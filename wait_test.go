@@ -0,0 +1,90 @@
+package robinhood
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nikunjy/robinhood/robinhoodtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitUntilDone(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.OrderLifecycle = []string{"unconfirmed", "confirmed", "queued", "filled"}
+	c := dialTest(t, srv)
+	spy := &Instrument{URL: srv.URL() + "instruments/spy/", Symbol: "SPY"}
+
+	out, err := c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(2, 0), Price: NewDecimal(100, 0)})
+	require.NoError(t, err)
+	changes := make(chan OrderState, 10)
+	require.NoError(t, out.WaitUntilDone(ctx, time.Millisecond, changes))
+	close(changes)
+	var states []OrderState
+	for s := range changes {
+		states = append(states, s)
+	}
+	require.Equal(t, []OrderState{Confirmed, Queued, Filled}, states)
+	require.Equal(t, "2", out.CumulativeQuantity.String())
+
+	// Done orders return at once.
+	require.NoError(t, out.WaitUntilDone(ctx, time.Hour, nil))
+
+	out, err = c.Order(ctx, spy, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(2, 0), Price: NewDecimal(100, 0)})
+	require.NoError(t, err)
+	require.NoError(t, srv.RejectOrder(out.ID, "Insufficient buying power."))
+	err = out.WaitUntilDone(ctx, time.Millisecond, nil)
+	var rejected *OrderRejectedError
+	require.True(t, errors.As(err, &rejected))
+	require.Equal(t, &OrderRejectedError{ID: out.ID, State: Rejected, Reason: "Insufficient buying power."}, rejected)
+	require.EqualError(t, err, "order "+out.ID+" rejected: Insufficient buying power.")
+}
+
+func TestWaitUntilDoneContext(t *testing.T) {
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	c := dialTest(t, srv)
+	spy := &Instrument{URL: srv.URL() + "instruments/spy/", Symbol: "SPY"}
+
+	out, err := c.Order(context.Background(), spy, OrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(100, 0)})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, out.WaitUntilDone(ctx, time.Millisecond, nil))
+	require.Equal(t, Queued, out.State)
+}
+
+func TestWaitUntilDoneCryptoAndOptions(t *testing.T) {
+	ctx := context.Background()
+	srv := robinhoodtest.NewServer()
+	defer srv.Close()
+	srv.OrderLifecycle = []string{"unconfirmed", "confirmed", "filled"}
+	c := dialTest(t, srv)
+
+	pair := CryptoCurrencyPair{ID: "btc", MinOrderPriceIncrement: MustParseDecimal("0.01"), CyrptoAssetCurrency: AssetCurrency{Increment: MustParseDecimal("0.00000001")}}
+	co, err := c.CryptoOrder(ctx, pair, CryptoOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(30000, 0)})
+	require.NoError(t, err)
+	require.NoError(t, co.WaitUntilDone(ctx, time.Millisecond, nil))
+	require.Equal(t, Filled, co.State)
+
+	oi := &OptionInstrument{URL: srv.URL() + "options/instruments/x/"}
+	ref := NewRefID()
+	_, err = c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(2, 0), RefID: ref})
+	require.NoError(t, err)
+	oo, err := c.FindOptionsOrderByRefID(ctx, ref)
+	require.NoError(t, err)
+	require.NoError(t, srv.SetOrderState(oo.ID, "failed"))
+	err = oo.WaitUntilDone(ctx, time.Millisecond, nil)
+	require.EqualError(t, err, "order "+oo.ID+" failed")
+
+	oo, err = c.OrderOptions(ctx, oi, OptionsOrderOpts{Side: Buy, Type: Limit, Quantity: NewDecimal(1, 0), Price: NewDecimal(2, 0)})
+	require.NoError(t, err)
+	require.NoError(t, srv.RejectOrder(oo.ID, "Insufficient buying power."))
+	err = oo.WaitUntilDone(ctx, time.Millisecond, nil)
+	var rejected *OrderRejectedError
+	require.True(t, errors.As(err, &rejected))
+	require.Equal(t, &OrderRejectedError{ID: oo.ID, State: Rejected, Reason: "Insufficient buying power."}, rejected)
+}
@@ -18,49 +18,42 @@ type Watchlist struct {
 
 // GetWatchlists retrieves the watchlists for a given set of credentials/accounts.
 func (c *Client) GetWatchlists(ctx context.Context) ([]Watchlist, error) {
-	var r struct{ Results []Watchlist }
-	err := c.GetAndDecode(ctx, EPWatchlists, &r)
-	if err != nil {
+	var ws []Watchlist
+	if err := c.getAll(ctx, EPWatchlists, &ws); err != nil {
 		return nil, err
 	}
-	if r.Results != nil {
-		for i := range r.Results {
-			r.Results[i].Client = c
-		}
+	for i := range ws {
+		ws[i].Client = c
 	}
-	return r.Results, nil
+	return ws, nil
 }
 
 // GetInstruments returns the list of Instruments associated with a Watchlist.
 func (w *Watchlist) GetInstruments(ctx context.Context) ([]Instrument, error) {
-	var r struct {
-		Results []struct {
-			Instrument, URL string
-		}
+	var items []struct {
+		Instrument, URL string
 	}
-
-	err := w.Client.GetAndDecode(ctx, w.URL, &r)
-	if err != nil {
+	if err := w.Client.getAll(ctx, w.URL, &items); err != nil {
 		return nil, err
 	}
 
-	insts := make([]*Instrument, len(r.Results))
+	insts := make([]*Instrument, len(items))
 	eg, ctx := errgroup.WithContext(ctx)
 
-	for i := range r.Results {
+	for i := range items {
 		// shadow for safe closure access
 		i := i
 		eg.Go(func() error {
-			inst, err := w.Client.GetInstrument(ctx, r.Results[i].Instrument)
+			inst, err := w.Client.GetInstrument(ctx, items[i].Instrument)
 			insts[i] = inst
 			return err
 		})
 	}
 
-	err = eg.Wait()
+	err := eg.Wait()
 
 	// Filter slice for empties (if error)
-	retInsts := make([]Instrument, 0, len(r.Results))
+	retInsts := make([]Instrument, 0, len(items))
 	for _, inst := range insts {
 		if inst != nil {
 			retInsts = append(retInsts, *inst)